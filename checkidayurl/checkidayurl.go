@@ -0,0 +1,207 @@
+// Package checkidayurl parses, builds and validates checkiday.com Event and date page URLs,
+// so pasted links can be accepted wherever an Event Id or date is.
+package checkidayurl
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The host of canonical URLs
+const Host = "www.checkiday.com"
+
+// The kinds of checkiday.com pages
+type Kind int
+
+const (
+	OtherPage Kind = iota // Any other page, e.g. an image
+	EventPage             // An Event page, e.g. /f90b893ea04939d7456f30c54f68d7b4/international-cat-day
+	DatePage              // A date page, e.g. /8/8
+)
+
+// The error returned for URLs that aren't on checkiday.com
+var ErrNotCheckiday = errors.New("not a checkiday.com URL")
+
+var (
+	eventId   = regexp.MustCompile(`^[0-9a-f]{32}$`)
+	slug      = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	number    = regexp.MustCompile(`^\d{1,2}$`)
+	slugBreak = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// An Event page
+type Event struct {
+	Id   string // The Event Id, empty if the URL only has a slug
+	Slug string // The URL slug of the Event name, e.g. "international-cat-day". Empty if the URL only has an Id.
+}
+
+// A date page
+type Date struct {
+	Month time.Month // The month
+	Day   int        // The day of the month
+}
+
+// A parsed checkiday.com page URL
+type Page struct {
+	Kind  Kind   // What the page is
+	Path  string // The URL path
+	Event Event  // The Event, for EventPages
+	Date  Date   // The date, for DatePages
+}
+
+// Parses a checkiday.com URL. Paths starting with "/" and URLs without a scheme, like www.checkiday.com/8/8, are accepted.
+// Anything else without a checkiday.com host, like a bare word or example.com/8/8, fails with ErrNotCheckiday.
+func Parse(raw string) (Page, error) {
+	u, err := parseUrl(raw)
+	if err != nil {
+		return Page{}, err
+	}
+
+	page := Page{Kind: OtherPage, Path: u.Path}
+	var segments []string
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	switch {
+	case len(segments) == 2 && number.MatchString(segments[0]) && number.MatchString(segments[1]):
+		month, _ := strconv.Atoi(segments[0])
+		day, _ := strconv.Atoi(segments[1])
+		if ValidDate(time.Month(month), day) {
+			page.Kind = DatePage
+			page.Date = Date{Month: time.Month(month), Day: day}
+		}
+	case len(segments) >= 1 && len(segments) <= 2 && IsEventId(segments[0]):
+		page.Kind = EventPage
+		page.Event.Id = segments[0]
+		if len(segments) == 2 {
+			page.Event.Slug = segments[1]
+		}
+	case len(segments) == 1 && slug.MatchString(segments[0]) && !number.MatchString(segments[0]):
+		page.Kind = EventPage
+		page.Event.Slug = segments[0]
+	}
+
+	return page, nil
+}
+
+// Parses an Event page URL, or a bare Event Id
+func ParseEvent(raw string) (Event, error) {
+	if IsEventId(strings.TrimSpace(raw)) {
+		return Event{Id: strings.TrimSpace(raw)}, nil
+	}
+	page, err := Parse(raw)
+	if err != nil {
+		return Event{}, err
+	}
+	if page.Kind != EventPage {
+		return Event{}, fmt.Errorf("%q is not an event URL", raw)
+	}
+	return page.Event, nil
+}
+
+// Parses a date page URL
+func ParseDate(raw string) (Date, error) {
+	page, err := Parse(raw)
+	if err != nil {
+		return Date{}, err
+	}
+	if page.Kind != DatePage {
+		return Date{}, fmt.Errorf("%q is not a date URL", raw)
+	}
+	return page.Date, nil
+}
+
+// Reports whether raw is a valid Event or date page URL
+func Valid(raw string) bool {
+	page, err := Parse(raw)
+	return err == nil && page.Kind != OtherPage
+}
+
+// Reports whether s is an Event Id: 32 lowercase hexadecimal characters
+func IsEventId(s string) bool {
+	return eventId.MatchString(s)
+}
+
+// Reports whether a month and day exist in some year
+func ValidDate(month time.Month, day int) bool {
+	if month < time.January || month > time.December || day < 1 {
+		return false
+	}
+	// 2000 is a leap year, so February 29th is valid
+	return day <= time.Date(2000, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Gets the URL slug of an Event name, e.g. "sneak-some-zucchini-onto-your-neighbors-porch-day"
+func Slug(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("'", "", "’", "").Replace(name)
+	return strings.Trim(slugBreak.ReplaceAllString(name, "-"), "-")
+}
+
+// Builds the canonical URL of an Event page from its Id and name
+func EventURL(id, name string) string {
+	return Event{Id: id, Slug: Slug(name)}.String()
+}
+
+// Builds the canonical URL of a date page
+func DateURL(month time.Month, day int) string {
+	return Date{Month: month, Day: day}.String()
+}
+
+// Gets the Event page's canonical URL
+func (e Event) String() string {
+	path := ""
+	for _, segment := range []string{e.Id, e.Slug} {
+		if segment != "" {
+			path += "/" + segment
+		}
+	}
+	return "https://" + Host + path
+}
+
+// Gets the date page's canonical URL
+func (d Date) String() string {
+	return fmt.Sprintf("https://%s/%d/%d", Host, int(d.Month), d.Day)
+}
+
+// Gets the date in the given year, at midnight in loc
+func (d Date) In(year int, loc *time.Location) time.Time {
+	return time.Date(year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// Parses raw as a checkiday.com URL, adding the scheme if it's missing
+func parseUrl(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse URL %q: %w", raw, err)
+	}
+	if u.Scheme == "" && u.Host == "" {
+		lower := strings.ToLower(raw)
+		if strings.HasPrefix(lower, "checkiday.com/") || strings.HasPrefix(lower, "www.checkiday.com/") {
+			// a URL without a scheme, e.g. www.checkiday.com/8/8
+			u, err = url.Parse("https://" + raw)
+			if err != nil {
+				return nil, fmt.Errorf("can't parse URL %q: %w", raw, err)
+			}
+		}
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if u.Host != "" && host != "checkiday.com" || u.Scheme != "" && u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", ErrNotCheckiday, raw)
+	}
+	if u.Host == "" && !strings.HasPrefix(raw, "/") {
+		// a bare word or another site without a scheme, e.g. "cat" or example.com/8/8
+		return nil, fmt.Errorf("%w: %q", ErrNotCheckiday, raw)
+	}
+	return u, nil
+}
@@ -0,0 +1,162 @@
+package checkidayurl
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("parses event pages", func(t *testing.T) {
+		for raw, want := range map[string]Event{
+			"https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day": {Id: "f90b893ea04939d7456f30c54f68d7b4", Slug: "international-cat-day"},
+			"http://checkiday.com/f90b893ea04939d7456f30c54f68d7b4/":                           {Id: "f90b893ea04939d7456f30c54f68d7b4"},
+			"www.checkiday.com/international-cat-day?utm_source=x":                             {Slug: "international-cat-day"},
+			"/international-cat-day": {Slug: "international-cat-day"},
+		} {
+			page, err := Parse(raw)
+
+			assert.Nil(t, err, raw)
+			assert.Equal(t, EventPage, page.Kind, raw)
+			assert.Equal(t, want, page.Event, raw)
+		}
+	})
+
+	t.Run("parses date pages", func(t *testing.T) {
+		for raw, want := range map[string]Date{
+			"https://www.checkiday.com/8/8": {Month: time.August, Day: 8},
+			"https://checkiday.com/02/29/":  {Month: time.February, Day: 29},
+			"CHECKIDAY.COM/12/31#top":       {Month: time.December, Day: 31},
+			"/1/1":                          {Month: time.January, Day: 1},
+		} {
+			page, err := Parse(raw)
+
+			assert.Nil(t, err, raw)
+			assert.Equal(t, DatePage, page.Kind, raw)
+			assert.Equal(t, want, page.Date, raw)
+		}
+	})
+
+	t.Run("parses other pages", func(t *testing.T) {
+		for _, raw := range []string{
+			"https://static.checkiday.com/img/600/kittens-555822.jpg",
+			"https://www.checkiday.com/img/600/kittens-555822.jpg",
+			"https://www.checkiday.com/2/30",
+			"https://www.checkiday.com/13/1",
+			"https://www.checkiday.com/8",
+			"https://www.checkiday.com/",
+			"/International Cat Day",
+		} {
+			page, err := Parse(raw)
+
+			if err == nil {
+				assert.Equal(t, OtherPage, page.Kind, raw)
+			} else {
+				assert.True(t, errors.Is(err, ErrNotCheckiday), raw)
+			}
+		}
+	})
+
+	t.Run("fails on other sites", func(t *testing.T) {
+		for _, raw := range []string{
+			"https://example.com/8/8", "mailto:hi@checkiday.com", "javascript:alert(1)", "https://checkiday.com.example.com/8/8",
+			"example.com/8/8", "international-cat-day", "8/8", "//example.com/8/8",
+		} {
+			_, err := Parse(raw)
+
+			assert.True(t, errors.Is(err, ErrNotCheckiday), raw)
+		}
+	})
+
+	t.Run("fails on invalid URLs", func(t *testing.T) {
+		_, err := Parse("https://www.checkiday.com/%zz")
+
+		assert.ErrorContains(t, err, "can't parse URL \"https://www.checkiday.com/%zz\"")
+	})
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Run("accepts ids", func(t *testing.T) {
+		event, err := ParseEvent(" f90b893ea04939d7456f30c54f68d7b4 ")
+
+		assert.Nil(t, err)
+		assert.Equal(t, Event{Id: "f90b893ea04939d7456f30c54f68d7b4"}, event)
+	})
+
+	t.Run("fails on date pages", func(t *testing.T) {
+		_, err := ParseEvent("https://www.checkiday.com/8/8")
+
+		assert.EqualError(t, err, `"https://www.checkiday.com/8/8" is not an event URL`)
+	})
+}
+
+func TestParseDate(t *testing.T) {
+	t.Run("parses date pages", func(t *testing.T) {
+		date, err := ParseDate("https://www.checkiday.com/8/8")
+
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2025, time.August, 8, 0, 0, 0, 0, time.UTC), date.In(2025, time.UTC))
+	})
+
+	t.Run("fails on event pages", func(t *testing.T) {
+		_, err := ParseDate("/international-cat-day")
+
+		assert.EqualError(t, err, `"/international-cat-day" is not a date URL`)
+	})
+}
+
+func TestValid(t *testing.T) {
+	t.Run("validates URLs", func(t *testing.T) {
+		assert.True(t, Valid("https://www.checkiday.com/8/8"))
+		assert.True(t, Valid("https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day"))
+		assert.False(t, Valid("https://www.checkiday.com/2/31"))
+		assert.False(t, Valid("https://example.com/8/8"))
+		assert.False(t, Valid("hello"))
+		assert.False(t, Valid("cat"))
+		assert.True(t, Valid("/international-cat-day"))
+	})
+
+	t.Run("validates ids", func(t *testing.T) {
+		assert.True(t, IsEventId("f90b893ea04939d7456f30c54f68d7b4"))
+		assert.False(t, IsEventId("F90B893EA04939D7456F30C54F68D7B4"))
+		assert.False(t, IsEventId("f90b893ea04939d7456f30c54f68d7b"))
+	})
+
+	t.Run("validates dates", func(t *testing.T) {
+		assert.True(t, ValidDate(time.February, 29))
+		assert.False(t, ValidDate(time.April, 31))
+		assert.False(t, ValidDate(0, 1))
+		assert.False(t, ValidDate(time.January, 0))
+	})
+}
+
+func TestBuild(t *testing.T) {
+	t.Run("builds event URLs", func(t *testing.T) {
+		assert.Equal(t, "https://www.checkiday.com/61363236f06e4eb8e4e14e5925c2503d/sneak-some-zucchini-onto-your-neighbors-porch-day",
+			EventURL("61363236f06e4eb8e4e14e5925c2503d", "Sneak Some Zucchini Onto Your Neighbor's Porch Day"))
+		assert.Equal(t, "https://www.checkiday.com/decc6d9d46ac1e40bf345d963fe2a7a2/national-childrens-mental-health-awareness-week",
+			EventURL("decc6d9d46ac1e40bf345d963fe2a7a2", "National Children’s Mental Health Awareness Week"))
+		assert.Equal(t, "https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4", Event{Id: "f90b893ea04939d7456f30c54f68d7b4"}.String())
+	})
+
+	t.Run("builds date URLs", func(t *testing.T) {
+		assert.Equal(t, "https://www.checkiday.com/8/8", DateURL(time.August, 8))
+	})
+
+	t.Run("round trips", func(t *testing.T) {
+		raw := EventURL("f90b893ea04939d7456f30c54f68d7b4", "International Cat Day!")
+
+		event, err := ParseEvent(raw)
+
+		assert.Nil(t, err)
+		assert.Equal(t, Event{Id: "f90b893ea04939d7456f30c54f68d7b4", Slug: "international-cat-day"}, event)
+	})
+
+	t.Run("slugs names", func(t *testing.T) {
+		assert.Equal(t, "cinco-de-mayo", Slug("Cinco de Mayo"))
+		assert.Equal(t, "fish-chips-day", Slug("  Fish & Chips Day  "))
+		assert.Equal(t, "", Slug("!!"))
+	})
+}
@@ -0,0 +1,468 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// How long to wait after the last keystroke before searching
+const searchDelay = 300 * time.Millisecond
+
+// The shortest query the API searches for
+const minQueryLength = 3
+
+// A key press
+type key string
+
+const (
+	keyUp        key = "up"
+	keyDown      key = "down"
+	keyLeft      key = "left"
+	keyRight     key = "right"
+	keyEnter     key = "enter"
+	keyEsc       key = "esc"
+	keyBackspace key = "backspace"
+	keyCtrlC     key = "ctrl+c"
+)
+
+// A browser screen
+type mode int
+
+const (
+	modeDay mode = iota
+	modeSearch
+	modeInfo
+)
+
+// The state of the interactive event browser. It is driven by key presses and clock ticks, and drawn by view.
+type browser struct {
+	client   Client
+	timezone string
+	adult    bool
+	now      func() time.Time
+
+	mode   mode
+	back   mode // the mode to return to from modeInfo
+	date   time.Time
+	days   map[time.Time]*holidays.GetEventsResponse
+	cursor int
+
+	query       string
+	searchAt    time.Time // when to search for query, zero if no search is pending
+	searched    string
+	results     []holidays.EventSummary
+	searchError string
+
+	infos  map[string]*holidays.EventInfo
+	info   *holidays.EventInfo
+	scroll int
+
+	status string
+	quit   bool
+}
+
+// A row of the day list
+type dayRow struct {
+	event holidays.EventSummary
+	label string
+}
+
+func newBrowser(client Client, date time.Time, timezone string, adult bool, now func() time.Time) *browser {
+	b := &browser{
+		client:   client,
+		timezone: timezone,
+		adult:    adult,
+		now:      now,
+		date:     date,
+		days:     map[time.Time]*holidays.GetEventsResponse{},
+		infos:    map[string]*holidays.EventInfo{},
+	}
+	b.loadDay()
+	return b
+}
+
+// Handles a key press
+func (b *browser) handle(k key) {
+	if k == keyCtrlC {
+		b.quit = true
+		return
+	}
+	b.status = ""
+
+	switch b.mode {
+	case modeDay:
+		b.handleDay(k)
+	case modeSearch:
+		b.handleSearch(k)
+	case modeInfo:
+		b.handleInfo(k)
+	}
+}
+
+func (b *browser) handleDay(k key) {
+	rows := b.dayRows()
+	switch k {
+	case keyLeft, "h", "p":
+		b.date = b.date.AddDate(0, 0, -1)
+		b.cursor = 0
+		b.loadDay()
+	case keyRight, "l", "n":
+		b.date = b.date.AddDate(0, 0, 1)
+		b.cursor = 0
+		b.loadDay()
+	case "t":
+		now := b.now()
+		b.date = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		b.cursor = 0
+		b.loadDay()
+	case keyUp, "k":
+		b.move(-1, len(rows))
+	case keyDown, "j":
+		b.move(1, len(rows))
+	case keyEnter:
+		if b.cursor < len(rows) {
+			b.open(rows[b.cursor].event, modeDay)
+		}
+	case "/", "s":
+		b.mode = modeSearch
+		b.cursor = 0
+	case "q", keyEsc:
+		b.quit = true
+	}
+}
+
+func (b *browser) handleSearch(k key) {
+	switch k {
+	case keyUp:
+		b.move(-1, len(b.results))
+	case keyDown:
+		b.move(1, len(b.results))
+	case keyEnter:
+		if b.cursor < len(b.results) {
+			b.open(b.results[b.cursor], modeSearch)
+		}
+	case keyEsc:
+		b.mode = modeDay
+		b.cursor = 0
+		b.searchAt = time.Time{}
+	case keyBackspace:
+		if b.query != "" {
+			_, size := utf8.DecodeLastRuneInString(b.query)
+			b.setQuery(b.query[:len(b.query)-size])
+		}
+	case keyLeft, keyRight:
+	default:
+		if utf8.RuneCountInString(string(k)) == 1 {
+			b.setQuery(b.query + string(k))
+		}
+	}
+}
+
+func (b *browser) handleInfo(k key) {
+	switch k {
+	case keyUp, "k":
+		if b.scroll > 0 {
+			b.scroll--
+		}
+	case keyDown, "j":
+		b.scroll++
+	case keyEsc, keyBackspace, keyLeft, "h":
+		b.mode = b.back
+		b.info = nil
+		b.scroll = 0
+	case "q":
+		b.quit = true
+	}
+}
+
+// Changes the search query, scheduling a search once typing pauses
+func (b *browser) setQuery(query string) {
+	b.query = query
+	b.cursor = 0
+	b.searchAt = b.now().Add(searchDelay)
+}
+
+// Runs a pending search if its delay has passed. Queries shorter than minQueryLength are not searched.
+func (b *browser) tick() {
+	if b.searchAt.IsZero() || b.now().Before(b.searchAt) {
+		return
+	}
+	b.searchAt = time.Time{}
+
+	query := strings.TrimSpace(b.query)
+	if utf8.RuneCountInString(query) < minQueryLength {
+		b.searched = ""
+		b.results = nil
+		b.searchError = ""
+		return
+	}
+	if query == b.searched {
+		return
+	}
+
+	res, err := b.client.Search(holidays.SearchRequest{Query: query, Adult: b.adult})
+	b.searched = query
+	b.results = nil
+	b.searchError = ""
+	if err != nil {
+		b.searchError = err.Error()
+		return
+	}
+	b.results = res.Events
+}
+
+// Gets when the next tick is needed, zero if none is
+func (b *browser) nextTick() time.Time {
+	return b.searchAt
+}
+
+func (b *browser) move(delta, n int) {
+	b.cursor += delta
+	if b.cursor >= n {
+		b.cursor = n - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+}
+
+// Loads the Events of the current date, unless they were already loaded
+func (b *browser) loadDay() {
+	if _, ok := b.days[b.date]; ok {
+		return
+	}
+	res, err := b.client.GetEvents(holidays.GetEventsRequest{
+		Date:     b.date.Format(holidays.DateLayout),
+		Timezone: b.timezone,
+		Adult:    b.adult,
+	})
+	if err != nil {
+		b.status = err.Error()
+		return
+	}
+	b.days[b.date] = res
+}
+
+// Opens an Event's info, remembering which mode to go back to
+func (b *browser) open(event holidays.EventSummary, from mode) {
+	info, ok := b.infos[event.Id]
+	if !ok {
+		res, err := b.client.GetEventInfo(holidays.GetEventInfoRequest{Id: event.Id})
+		if err != nil {
+			b.status = err.Error()
+			return
+		}
+		info = &res.Event
+		b.infos[event.Id] = info
+	}
+	b.info = info
+	b.back = from
+	b.mode = modeInfo
+	b.scroll = 0
+}
+
+// Gets the rows of the current date's list
+func (b *browser) dayRows() []dayRow {
+	res, ok := b.days[b.date]
+	if !ok {
+		return nil
+	}
+	var rows []dayRow
+	for _, event := range res.Events {
+		rows = append(rows, dayRow{event, event.Name})
+	}
+	for _, event := range res.MultidayStarting {
+		rows = append(rows, dayRow{event, event.Name + " (starts)"})
+	}
+	for _, event := range res.MultidayOngoing {
+		rows = append(rows, dayRow{event, event.Name + " (ongoing)"})
+	}
+	return rows
+}
+
+// Draws the screen as lines of at most width characters, at most height lines
+func (b *browser) view(width, height int) []string {
+	var header []string
+	var body []string
+	var footer string
+	cursor := -1
+
+	switch b.mode {
+	case modeDay:
+		header = []string{b.date.Format("Monday, January 2, 2006"), ""}
+		rows := b.dayRows()
+		for _, row := range rows {
+			body = append(body, row.label)
+		}
+		if _, ok := b.days[b.date]; ok && len(rows) == 0 {
+			body = []string{"No events."}
+		} else {
+			cursor = b.cursor
+		}
+		footer = "←/→ day  ↑/↓ select  enter open  / search  t today  q quit"
+	case modeSearch:
+		header = []string{"Search: " + b.query + "_", ""}
+		switch {
+		case utf8.RuneCountInString(strings.TrimSpace(b.query)) < minQueryLength:
+			body = []string{"Type at least " + strconv.Itoa(minQueryLength) + " characters."}
+		case b.searchError != "":
+			body = []string{b.searchError}
+		case b.searched == "" || !b.searchAt.IsZero():
+			body = []string{"Searching…"}
+		case len(b.results) == 0:
+			body = []string{"No events found."}
+		default:
+			for _, event := range b.results {
+				body = append(body, event.Name)
+			}
+			cursor = b.cursor
+		}
+		footer = "type to search  ↑/↓ select  enter open  esc back"
+	case modeInfo:
+		header = []string{b.info.Name, b.info.Url, ""}
+		body = infoLines(*b.info, width)
+		maxScroll := len(body) - (height - len(header) - 2)
+		if maxScroll < 0 {
+			maxScroll = 0
+		}
+		if b.scroll > maxScroll {
+			b.scroll = maxScroll
+		}
+		body = body[b.scroll:]
+		footer = "↑/↓ scroll  esc back  q quit"
+	}
+
+	// keep the cursor visible
+	room := height - len(header) - 2
+	if room < 1 {
+		room = 1
+	}
+	offset := 0
+	if cursor >= room {
+		offset = cursor - room + 1
+	}
+	if offset > len(body) {
+		offset = len(body)
+	}
+	body = body[offset:]
+	if len(body) > room {
+		body = body[:room]
+	}
+
+	lines := append([]string{}, header...)
+	for i, line := range body {
+		prefix := "  "
+		if cursor >= 0 && i+offset == cursor {
+			prefix = "> "
+		}
+		if b.mode == modeInfo {
+			prefix = ""
+		}
+		lines = append(lines, prefix+line)
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	if b.status != "" {
+		footer = b.status
+	}
+	lines = append(lines, footer)
+
+	for i, line := range lines {
+		lines[i] = clip(line, width)
+	}
+	return lines
+}
+
+// Gets the lines describing an Event: its description, patterns, occurrences and founders
+func infoLines(info holidays.EventInfo, width int) []string {
+	var lines []string
+	section := func(title string, content []string) {
+		if len(content) == 0 {
+			return
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, title)
+		lines = append(lines, content...)
+	}
+
+	section("Description", wrap(info.Description.Text, width))
+
+	var patterns []string
+	for _, pattern := range info.Patterns {
+		years := ""
+		if first, ok := pattern.FirstYear.Get(); ok {
+			years = fmt.Sprintf(" (since %d)", first)
+			if last, ok := pattern.LastYear.Get(); ok {
+				years = fmt.Sprintf(" (%d–%d)", first, last)
+			}
+		}
+		patterns = append(patterns, wrap("• "+pattern.Observed+years, width)...)
+	}
+	section("Observed", patterns)
+
+	var occurrences []string
+	for _, occurrence := range info.Occurrences {
+		line := "• " + occurrence.Date
+		if date, err := occurrence.Time(); err == nil {
+			line = "• " + date.Format("Mon, Jan 2, 2006")
+		}
+		if occurrence.Length > 1 {
+			line += fmt.Sprintf(" (%d days)", occurrence.Length)
+		}
+		occurrences = append(occurrences, line)
+	}
+	section("Occurrences", occurrences)
+
+	var founders []string
+	for _, founder := range info.Founders {
+		line := "• " + founder.Name
+		if founder.Date != "" {
+			line += " (" + founder.Date + ")"
+		}
+		if founder.Url != "" {
+			line += " " + founder.Url
+		}
+		founders = append(founders, wrap(line, width)...)
+	}
+	section("Founders", founders)
+
+	return lines
+}
+
+// Wraps text at word boundaries into lines of at most width characters
+func wrap(text string, width int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Cuts a line to at most width characters
+func clip(line string, width int) string {
+	if utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	return string([]rune(line)[:width])
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+// A clock that only moves when told to
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.t = c.t.Add(d)
+}
+
+func browserClient(t *testing.T) *fakeClient {
+	day := testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json")
+	info := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json")
+	search := testutil.LoadFixture[holidays.SearchResponse](t, "search-default.json")
+	return &fakeClient{
+		events:   map[string]*holidays.GetEventsResponse{"05/05/2025": day},
+		infos:    map[string]*holidays.GetEventInfoResponse{day.Events[0].Id: info, search.Events[0].Id: info},
+		searches: map[string]*holidays.SearchResponse{"zucchini": search},
+	}
+}
+
+func newTestBrowser(client Client) (*browser, *fakeClock) {
+	clock := &fakeClock{time.Date(2025, time.May, 5, 12, 0, 0, 0, time.UTC)}
+	return newBrowser(client, time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC), "America/Chicago", false, clock.now), clock
+}
+
+func screen(b *browser) string {
+	return strings.Join(b.view(80, 24), "\n")
+}
+
+func TestBrowser(t *testing.T) {
+	t.Run("shows the day", func(t *testing.T) {
+		client := browserClient(t)
+		b, _ := newTestBrowser(client)
+
+		lines := b.view(80, 24)
+
+		assert.Equal(t, 24, len(lines))
+		assert.Equal(t, "Monday, May 5, 2025", lines[0])
+		assert.Equal(t, "> Cinco de Mayo", lines[2])
+		assert.Equal(t, "  Great Lakes Awareness Day", lines[3])
+		assert.Equal(t, "  Teacher Appreciation Week (starts)", lines[4])
+		assert.Equal(t, "  Be Kind to Animals Week (ongoing)", lines[5])
+		assert.Contains(t, lines[23], "q quit")
+		assert.Equal(t, []any{holidays.GetEventsRequest{Date: "05/05/2025", Timezone: "America/Chicago"}}, client.requests)
+	})
+
+	t.Run("pages through days", func(t *testing.T) {
+		client := browserClient(t)
+		b, _ := newTestBrowser(client)
+
+		b.handle(keyRight)
+		assert.Contains(t, screen(b), "Tuesday, May 6, 2025\n\n  No events.")
+
+		b.handle(keyLeft)
+		b.handle(keyLeft)
+		assert.Contains(t, screen(b), "Sunday, May 4, 2025")
+
+		b.handle("t")
+		assert.Contains(t, screen(b), "Monday, May 5, 2025")
+		// days are only fetched once
+		assert.Equal(t, 3, len(client.requests))
+	})
+
+	t.Run("moves the cursor within the list", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+
+		b.handle(keyUp)
+		assert.Equal(t, 0, b.cursor)
+		for i := 0; i < 10; i++ {
+			b.handle(keyDown)
+		}
+		assert.Equal(t, 4, b.cursor)
+		assert.Contains(t, screen(b), "> National Children's Mental Health Awareness Week (ongoing)")
+	})
+
+	t.Run("scrolls long lists", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+		for i := 0; i < 4; i++ {
+			b.handle(keyDown)
+		}
+
+		lines := b.view(80, 6)
+
+		assert.Equal(t, []string{
+			"Monday, May 5, 2025",
+			"",
+			"  Be Kind to Animals Week (ongoing)",
+			"> National Children's Mental Health Awareness Week (ongoing)",
+			"",
+			"←/→ day  ↑/↓ select  enter open  / search  t today  q quit",
+		}, lines)
+		assert.Equal(t, "←/→ day  ↑/↓ select  enter ope", b.view(30, 6)[5])
+	})
+
+	t.Run("opens event info", func(t *testing.T) {
+		client := browserClient(t)
+		b, _ := newTestBrowser(client)
+
+		b.handle(keyEnter)
+		text := screen(b)
+
+		assert.Equal(t, modeInfo, b.mode)
+		assert.Contains(t, text, "International Cat Day\nhttps://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day\n\nDescription\n")
+		assert.Contains(t, text, "Observed\n• annually on August 8th (since 2002)")
+		assert.Contains(t, text, "Occurrences\n• Sat, Aug 8, 2020")
+		assert.Contains(t, text, "Founders\n• International Fund For Animal Welfare (2002) https://www.ifaw.org/")
+
+		b.handle(keyEsc)
+		assert.Equal(t, modeDay, b.mode)
+
+		b.handle(keyEnter)
+		// event info is only fetched once
+		assert.Equal(t, 2, len(client.requests))
+	})
+
+	t.Run("scrolls event info", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+		b.handle(keyEnter)
+		top := b.view(80, 10)
+
+		b.handle(keyDown)
+		b.handle(keyDown)
+		scrolled := b.view(80, 10)
+
+		assert.Equal(t, top[:3], scrolled[:3])
+		assert.Equal(t, top[5], scrolled[3])
+
+		for i := 0; i < 100; i++ {
+			b.handle(keyDown)
+		}
+		bottom := b.view(80, 10)
+		assert.Contains(t, strings.Join(bottom, "\n"), "International Fund For Animal Welfare")
+	})
+
+	t.Run("searches as you type", func(t *testing.T) {
+		client := browserClient(t)
+		b, clock := newTestBrowser(client)
+
+		b.handle("/")
+		b.handle("z")
+		b.handle("u")
+		clock.advance(time.Second)
+		b.tick()
+		assert.Contains(t, screen(b), "Search: zu_\n\n  Type at least 3 characters.")
+		assert.Equal(t, 1, len(client.requests))
+
+		for _, r := range "cchini" {
+			b.handle(key(string(r)))
+			clock.advance(100 * time.Millisecond)
+			b.tick()
+		}
+		// still typing, so nothing was searched yet
+		assert.Equal(t, 1, len(client.requests))
+		assert.Contains(t, screen(b), "Searching…")
+
+		clock.advance(searchDelay)
+		b.tick()
+		assert.Equal(t, holidays.SearchRequest{Query: "zucchini"}, client.requests[1])
+		assert.Contains(t, screen(b), "Search: zucchini_\n\n> National Zucchini Bread Day\n  National Zucchini Day")
+
+		// nothing changed, so nothing is searched again
+		b.handle("x")
+		b.handle(keyBackspace)
+		clock.advance(searchDelay)
+		b.tick()
+		assert.Equal(t, 2, len(client.requests))
+
+		b.handle(keyEnter)
+		assert.Equal(t, modeInfo, b.mode)
+		b.handle(keyEsc)
+		assert.Equal(t, modeSearch, b.mode)
+		b.handle(keyEsc)
+		assert.Equal(t, modeDay, b.mode)
+	})
+
+	t.Run("types q in searches", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+
+		b.handle("/")
+		b.handle("q")
+
+		assert.False(t, b.quit)
+		assert.Equal(t, "q", b.query)
+	})
+
+	t.Run("shows search errors", func(t *testing.T) {
+		client := browserClient(t)
+		b, clock := newTestBrowser(client)
+		b.handle("/")
+		for _, r := range "cats" {
+			b.handle(key(string(r)))
+		}
+		client.err = assert.AnError
+
+		clock.advance(searchDelay)
+		b.tick()
+
+		assert.Contains(t, screen(b), "Search: cats_\n\n  "+assert.AnError.Error())
+	})
+
+	t.Run("shows errors in the footer", func(t *testing.T) {
+		client := browserClient(t)
+		b, _ := newTestBrowser(client)
+		client.err = assert.AnError
+
+		b.handle(keyRight)
+		lines := b.view(200, 24)
+
+		assert.Equal(t, assert.AnError.Error(), lines[23])
+	})
+
+	t.Run("quits", func(t *testing.T) {
+		for _, k := range []key{"q", keyEsc, keyCtrlC} {
+			b, _ := newTestBrowser(browserClient(t))
+
+			b.handle(k)
+
+			assert.True(t, b.quit, k)
+		}
+	})
+}
+
+func TestWrap(t *testing.T) {
+	t.Run("wraps at words", func(t *testing.T) {
+		assert.Equal(t, []string{"one two", "three", "four five"}, wrap("one two three\nfour five", 9))
+	})
+
+	t.Run("keeps long words", func(t *testing.T) {
+		assert.Equal(t, []string{"a", "abcdefghij", "b"}, wrap("a abcdefghij b", 5))
+	})
+}
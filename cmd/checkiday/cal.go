@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/render"
+)
+
+// The number of concurrent GetEvents requests when fetching a month
+const fetchConcurrency = 4
+
+// ANSI escape codes
+const (
+	ansiBold  = "\x1b[1m"
+	ansiCyan  = "\x1b[36m"
+	ansiReset = "\x1b[0m"
+)
+
+func runCal(e env, args []string) error {
+	fs := e.flags("cal")
+	month := fs.String("month", "", "The month to show, as YYYY-MM. Defaults to this month.")
+	timezone := fs.String("timezone", "", "IANA Time Zone for calculating dates. Defaults to America/Chicago.")
+	adult := fs.Bool("adult", false, "Include events that may be unsafe for viewing at work or by children")
+	monday := fs.Bool("monday", false, "Start weeks on Monday")
+	noColor := fs.Bool("no-color", false, "Don't use ANSI colors. Also disabled by NO_COLOR or when not writing to a terminal.")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	first := time.Date(e.now().Year(), e.now().Month(), 1, 0, 0, 0, 0, time.UTC)
+	if *month != "" {
+		var err error
+		first, err = time.Parse("2006-01", *month)
+		if err != nil {
+			return fmt.Errorf("can't parse month %q, expected YYYY-MM", *month)
+		}
+	}
+
+	client, err := e.client()
+	if err != nil {
+		return err
+	}
+	days, err := fetchMonth(client, first, *timezone, *adult)
+	if err != nil {
+		return err
+	}
+
+	weekStart := time.Sunday
+	if *monday {
+		weekStart = time.Monday
+	}
+	cal, err := render.NewCalendar(days, weekStart)
+	if err != nil {
+		return err
+	}
+
+	printCal(e.stdout, cal, e.color(*noColor))
+	return nil
+}
+
+// Gets the Events of every day of the month starting on first, in order
+func fetchMonth(client Client, first time.Time, timezone string, adult bool) ([]*holidays.GetEventsResponse, error) {
+	n := first.AddDate(0, 1, -1).Day()
+	days := make([]*holidays.GetEventsResponse, n)
+	errs := make([]error, n)
+	semaphore := make(chan struct{}, fetchConcurrency)
+	var wg sync.WaitGroup
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			days[i], errs[i] = client.GetEvents(holidays.GetEventsRequest{
+				Date:     first.AddDate(0, 0, i).Format(holidays.DateLayout),
+				Timezone: timezone,
+				Adult:    adult,
+			})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("can't get events for %s: %w", first.AddDate(0, 0, i).Format(time.DateOnly), err)
+		}
+	}
+	return days, nil
+}
+
+// A multi-day Event listed under the day it starts
+type calSpan struct {
+	event      holidays.EventSummary
+	start, end time.Time
+	continuing bool // started in the previous month
+}
+
+// Prints a month grid like cal(1), marking days with Events, followed by each day's Events
+func printCal(w io.Writer, cal render.Calendar, color bool) {
+	style := func(s, code string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+
+	// each day is 3 columns wide: 2 for the day and 1 for the marker
+	title := cal.Title()
+	fmt.Fprintf(w, "%s%s\n", strings.Repeat(" ", (7*3-1-len(title))/2), title)
+	var header []string
+	for _, weekday := range cal.Weekdays {
+		header = append(header, weekday.String()[:2])
+	}
+	fmt.Fprintln(w, strings.Join(header, " "))
+
+	// multi-day Events by the day they start, and the days they cover
+	spans := map[time.Time][]calSpan{}
+	covered := map[time.Time]bool{}
+	seen := map[string]bool{}
+	for _, week := range cal.Weeks {
+		for _, lane := range week.Lanes {
+			for _, segment := range lane {
+				if segment.Event == nil {
+					continue
+				}
+				key := segment.Event.Id + segment.Start.String()
+				if !seen[key] {
+					seen[key] = true
+					spans[segment.Start] = append(spans[segment.Start], calSpan{
+						event:      *segment.Event,
+						start:      segment.Start,
+						end:        segment.End,
+						continuing: segment.StartedBefore,
+					})
+				}
+				for day := segment.Start; !day.After(segment.End); day = day.AddDate(0, 0, 1) {
+					covered[day] = true
+				}
+			}
+		}
+	}
+
+	for _, week := range cal.Weeks {
+		var line strings.Builder
+		for _, day := range week.Days {
+			if !day.InMonth {
+				line.WriteString("   ")
+				continue
+			}
+
+			hasEvents := len(day.Events) > 0 || len(spans[day.Date]) > 0
+			number := fmt.Sprintf("%2d", day.Day())
+			marker := " "
+			switch {
+			case color && covered[day.Date]:
+				number = style(number, ansiCyan+ansiBold)
+			case color && hasEvents:
+				number = style(number, ansiBold)
+			case hasEvents:
+				marker = "*"
+			}
+			line.WriteString(number + marker)
+		}
+		fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+	}
+
+	for _, week := range cal.Weeks {
+		for _, day := range week.Days {
+			if !day.InMonth || len(day.Events) == 0 && len(spans[day.Date]) == 0 {
+				continue
+			}
+
+			fmt.Fprintf(w, "\n%s\n", day.Date.Format("Mon, Jan 2"))
+			for _, event := range day.Events {
+				fmt.Fprintf(w, "  %s\n", event.Name)
+			}
+			for _, span := range spans[day.Date] {
+				when := "through " + span.end.Format("Jan 2")
+				if !span.continuing {
+					when = span.start.Format("Jan 2") + "–" + span.end.Format("Jan 2")
+					if span.start.Month() == span.end.Month() {
+						when = span.start.Format("Jan 2") + "–" + span.end.Format("2")
+					}
+				}
+				fmt.Fprintf(w, "  %s\n", style(span.event.Name+" ("+when+")", ansiCyan))
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+func may2025() *fakeClient {
+	kind := holidays.EventSummary{Id: "kind", Name: "Be Kind to Animals Week"}
+	teacher := holidays.EventSummary{Id: "teacher", Name: "Teacher Appreciation Week"}
+	return &fakeClient{events: map[string]*holidays.GetEventsResponse{
+		"05/01/2025": {Date: "05/01/2025", MultidayOngoing: []holidays.EventSummary{kind}},
+		"05/02/2025": {Date: "05/02/2025", MultidayOngoing: []holidays.EventSummary{kind}},
+		"05/05/2025": {Date: "05/05/2025", Events: []holidays.EventSummary{{Id: "cinco", Name: "Cinco de Mayo"}}, MultidayStarting: []holidays.EventSummary{teacher}},
+		"05/06/2025": {Date: "05/06/2025", MultidayOngoing: []holidays.EventSummary{teacher}},
+		"05/31/2025": {Date: "05/31/2025", Events: []holidays.EventSummary{{Id: "smile", Name: "Smile Day"}}},
+	}}
+}
+
+func TestCal(t *testing.T) {
+	t.Run("prints the month", func(t *testing.T) {
+		client := may2025()
+		e, stdout, _ := testEnv(client)
+
+		code := run(e, []string{"cal", "--timezone", "America/New_York"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, 31, len(client.requests))
+		assert.Contains(t, client.requests, holidays.GetEventsRequest{Date: "05/15/2025", Timezone: "America/New_York"})
+		assert.Equal(t, `      May 2025
+Su Mo Tu We Th Fr Sa
+             1* 2  3
+ 4  5* 6  7  8  9 10
+11 12 13 14 15 16 17
+18 19 20 21 22 23 24
+25 26 27 28 29 30 31*
+
+Thu, May 1
+  Be Kind to Animals Week (through May 2)
+
+Mon, May 5
+  Cinco de Mayo
+  Teacher Appreciation Week (May 5–6)
+
+Sat, May 31
+  Smile Day
+`, stdout.String())
+	})
+
+	t.Run("starts weeks on Monday", func(t *testing.T) {
+		e, stdout, _ := testEnv(may2025())
+
+		code := run(e, []string{"cal", "--monday", "--month", "2025-05"})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "Mo Tu We Th Fr Sa Su\n          1* 2  3  4\n")
+	})
+
+	t.Run("colors multi-day events", func(t *testing.T) {
+		e, stdout, _ := testEnv(may2025())
+		e.isTTY = true
+
+		code := run(e, []string{"cal"})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "\x1b[36m\x1b[1m 5\x1b[0m ")
+		assert.Contains(t, stdout.String(), "\x1b[1m31\x1b[0m")
+		assert.Contains(t, stdout.String(), "  \x1b[36mTeacher Appreciation Week (May 5–6)\x1b[0m\n")
+		assert.NotContains(t, stdout.String(), "*")
+	})
+
+	t.Run("disables colors", func(t *testing.T) {
+		e, stdout, _ := testEnv(may2025())
+		e.isTTY = true
+
+		code := run(e, []string{"cal", "--no-color"})
+
+		assert.Equal(t, 0, code)
+		assert.NotContains(t, stdout.String(), "\x1b[")
+	})
+
+	t.Run("fails on invalid months", func(t *testing.T) {
+		e, _, stderr := testEnv(may2025())
+
+		code := run(e, []string{"cal", "--month", "May"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: can't parse month \"May\", expected YYYY-MM\n", stderr.String())
+	})
+}
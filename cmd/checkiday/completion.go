@@ -0,0 +1,108 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Completion scripts by shell. Each asks "checkiday __complete" for candidates,
+// printed one per line as the value, optionally followed by a tab and a description.
+var completionScripts = map[string]string{
+	"bash": `_checkiday() {
+    local IFS=$'\n' line
+    COMPREPLY=()
+    for line in $(checkiday __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null); do
+        COMPREPLY+=("${line%%$'\t'*}")
+    done
+}
+complete -F _checkiday checkiday
+`,
+	"zsh": `#compdef checkiday
+_checkiday() {
+    local -a values descriptions
+    local line
+    for line in "${(@f)$(checkiday __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}"; do
+        [[ -z $line ]] && continue
+        values+=("${line%%$'\t'*}")
+        if [[ $line == *$'\t'* ]]; then
+            descriptions+=("${line%%$'\t'*}  -- ${line#*$'\t'}")
+        else
+            descriptions+=("$line")
+        fi
+    done
+    # -U keeps candidates that don't start with the typed word, so names complete to ids
+    compadd -U -l -d descriptions -a values
+}
+compdef _checkiday checkiday
+`,
+	"fish": `function __checkiday_complete
+    set -l tokens (commandline -opc) (commandline -ct)
+    # fish only keeps candidates matching the typed word, so ask for names rather than ids
+    checkiday __complete-names $tokens[2..-1] 2>/dev/null
+end
+complete -c checkiday -f -a '(__checkiday_complete)'
+`,
+}
+
+func runCompletion(e env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("completion needs a shell: bash, zsh or fish")
+	}
+	script, ok := completionScripts[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown shell %q, expected bash, zsh or fish", args[0])
+	}
+	fmt.Fprint(e.stdout, script)
+	return nil
+}
+
+// Prints completion candidates for the command line args, whose last element is the word being completed
+func runComplete(e env, args []string) error {
+	return complete(e, args, false)
+}
+
+// Prints completion candidates like runComplete, but events as names unless the word starts their id.
+// "info" resolves the names to ids.
+func runCompleteNames(e env, args []string) error {
+	return complete(e, args, true)
+}
+
+func complete(e env, args []string, names bool) error {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	word := args[len(args)-1]
+
+	if len(args) == 1 {
+		for _, cmd := range commands {
+			if !cmd.hidden && strings.HasPrefix(cmd.name, word) {
+				fmt.Fprintf(e.stdout, "%s\t%s\n", cmd.name, cmd.summary)
+			}
+		}
+		return nil
+	}
+
+	switch args[0] {
+	case "completion":
+		if len(args) == 2 {
+			for _, shell := range []string{"bash", "fish", "zsh"} {
+				if strings.HasPrefix(shell, word) {
+					fmt.Fprintln(e.stdout, shell)
+				}
+			}
+		}
+	case "info":
+		if strings.HasPrefix(word, "-") {
+			return nil
+		}
+		for _, entry := range e.history.complete(word) {
+			if names && !strings.HasPrefix(entry.Id, strings.ToLower(strings.TrimSpace(word))) {
+				fmt.Fprintf(e.stdout, "%s\t%s\n", entry.Name, entry.Id)
+			} else {
+				fmt.Fprintf(e.stdout, "%s\t%s\n", entry.Id, entry.Name)
+			}
+		}
+	}
+	return nil
+}
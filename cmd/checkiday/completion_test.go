@@ -0,0 +1,96 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletion(t *testing.T) {
+	t.Run("prints scripts", func(t *testing.T) {
+		for _, shell := range []string{"bash", "zsh", "fish"} {
+			e, stdout, _ := testEnv(&fakeClient{})
+
+			code := run(e, []string{"completion", shell})
+
+			assert.Equal(t, 0, code, shell)
+			assert.Contains(t, stdout.String(), "checkiday __complete", shell)
+		}
+	})
+
+	t.Run("fails on unknown shells", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"completion", "tcsh"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: unknown shell \"tcsh\", expected bash, zsh or fish\n", stderr.String())
+	})
+
+	t.Run("completes commands", func(t *testing.T) {
+		e, stdout, _ := testEnv(&fakeClient{})
+
+		code := run(e, []string{"__complete", "c"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "cal\tPrint a month calendar of holidays\ncompletion\tPrint a bash, zsh or fish completion script\n", stdout.String())
+	})
+
+	t.Run("completes shells", func(t *testing.T) {
+		e, stdout, _ := testEnv(&fakeClient{})
+
+		run(e, []string{"__complete", "completion", ""})
+
+		assert.Equal(t, "bash\nfish\nzsh\n", stdout.String())
+	})
+
+	t.Run("completes event ids from names", func(t *testing.T) {
+		e, stdout, _ := testEnv(&fakeClient{})
+		e.history.add(e.now(), cincoDay, catfishDay, catDay)
+
+		code := run(e, []string{"__complete", "info", "Cat"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, catDay.Id+"\tInternational Cat Day\n"+catfishDay.Id+"\tNational Catfish Day\n", stdout.String())
+	})
+
+	t.Run("completes event names for fish", func(t *testing.T) {
+		e, stdout, _ := testEnv(&fakeClient{})
+		e.history.add(e.now(), cincoDay, catfishDay, catDay)
+
+		code := run(e, []string{"completion", "fish"})
+
+		assert.Equal(t, 0, code)
+		assert.Contains(t, stdout.String(), "checkiday __complete-names $tokens[2..-1]")
+
+		stdout.Reset()
+		code = run(e, []string{"__complete-names", "info", "Cat"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, "International Cat Day\t"+catDay.Id+"\nNational Catfish Day\t"+catfishDay.Id+"\n", stdout.String())
+
+		stdout.Reset()
+		run(e, []string{"__complete-names", "info", "c6"})
+
+		assert.Equal(t, catfishDay.Id+"\tNational Catfish Day\n", stdout.String())
+	})
+
+	t.Run("resolves completed names", func(t *testing.T) {
+		e, _, _ := testEnv(&fakeClient{})
+		e.history.add(e.now(), catfishDay, catDay)
+
+		id, err := e.history.resolve("International Cat Day")
+
+		assert.Nil(t, err)
+		assert.Equal(t, catDay.Id, id)
+	})
+
+	t.Run("doesn't complete flags", func(t *testing.T) {
+		e, stdout, _ := testEnv(&fakeClient{})
+		e.history.add(e.now(), catDay)
+
+		run(e, []string{"__complete", "info", "--st"})
+
+		assert.Equal(t, "", stdout.String())
+	})
+}
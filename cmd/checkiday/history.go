@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/checkidayurl"
+)
+
+// The most Events the history remembers
+const historyMax = 1000
+
+// An Event seen in an API response
+type historyEntry struct {
+	Id   string    `json:"id"`
+	Name string    `json:"name"`
+	Seen time.Time `json:"seen"`
+}
+
+// The recently seen Events, stored in a local file so their ids can be completed
+type history struct {
+	path    string
+	mu      sync.Mutex
+	entries []historyEntry // most recently seen first
+	changed bool
+}
+
+// Loads the history from path. A missing or unreadable file is an empty history.
+func loadHistory(path string) *history {
+	h := &history{path: path}
+	if path == "" {
+		return h
+	}
+	if b, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(b, &h.entries)
+	}
+	return h
+}
+
+// Remembers that events were seen at now
+func (h *history) add(now time.Time, events ...holidays.EventSummary) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, event := range events {
+		if event.Id == "" {
+			continue
+		}
+		for i, entry := range h.entries {
+			if entry.Id == event.Id {
+				h.entries = append(h.entries[:i], h.entries[i+1:]...)
+				break
+			}
+		}
+		h.entries = append([]historyEntry{{Id: event.Id, Name: event.Name, Seen: now}}, h.entries...)
+		h.changed = true
+	}
+	if len(h.entries) > historyMax {
+		h.entries = h.entries[:historyMax]
+	}
+}
+
+// Writes the history to its file if it changed
+func (h *history) save() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.changed || h.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(h.entries)
+	if err != nil {
+		return fmt.Errorf("can't encode history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("can't write history: %w", err)
+	}
+	if err := os.WriteFile(h.path, b, 0o644); err != nil {
+		return fmt.Errorf("can't write history: %w", err)
+	}
+	h.changed = false
+	return nil
+}
+
+// Gets the Events whose id starts with prefix or whose name does from any word on, most recent first
+func (h *history) complete(prefix string) []historyEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	var matches []historyEntry
+	for _, entry := range h.entries {
+		if strings.HasPrefix(entry.Id, prefix) || nameMatches(entry.Name, prefix) {
+			matches = append(matches, entry)
+		}
+	}
+	return matches
+}
+
+// Gets the id of the Event that arg names: an id, an Event URL, a remembered name, or the start of exactly one remembered name
+func (h *history) resolve(arg string) (string, error) {
+	if event, err := checkidayurl.ParseEvent(arg); err == nil && event.Id != "" {
+		return event.Id, nil
+	}
+
+	matches := h.complete(arg)
+	for _, entry := range matches {
+		if strings.EqualFold(entry.Name, strings.TrimSpace(arg)) {
+			return entry.Id, nil
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no recently seen event matches %q", arg)
+	case 1:
+		return matches[0].Id, nil
+	}
+
+	names := make([]string, len(matches))
+	for i, entry := range matches {
+		names[i] = entry.Name
+	}
+	sort.Strings(names)
+	return "", fmt.Errorf("%q matches several events: %s", arg, strings.Join(names, ", "))
+}
+
+// Reports whether the part of name from the start of any of its words starts with prefix
+func nameMatches(name, prefix string) bool {
+	name = strings.ToLower(name)
+	for i := range name {
+		if (i == 0 || name[i-1] == ' ') && strings.HasPrefix(name[i:], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// A Client that remembers the Events in its responses
+type recordingClient struct {
+	Client
+	history *history
+	now     func() time.Time
+}
+
+func (c *recordingClient) GetEvents(req holidays.GetEventsRequest) (*holidays.GetEventsResponse, error) {
+	res, err := c.Client.GetEvents(req)
+	if err == nil {
+		c.history.add(c.now(), res.Events...)
+		c.history.add(c.now(), res.MultidayStarting...)
+		c.history.add(c.now(), res.MultidayOngoing...)
+	}
+	return res, err
+}
+
+func (c *recordingClient) GetEventInfo(req holidays.GetEventInfoRequest) (*holidays.GetEventInfoResponse, error) {
+	res, err := c.Client.GetEventInfo(req)
+	if err == nil {
+		c.history.add(c.now(), res.Event.EventSummary)
+	}
+	return res, err
+}
+
+func (c *recordingClient) Search(req holidays.SearchRequest) (*holidays.SearchResponse, error) {
+	res, err := c.Client.Search(req)
+	if err == nil {
+		c.history.add(c.now(), res.Events...)
+	}
+	return res, err
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+var (
+	catDay     = holidays.EventSummary{Id: "f90b893ea04939d7456f30c54f68d7b4", Name: "International Cat Day"}
+	catfishDay = holidays.EventSummary{Id: "c6e6f2f6de4e3d2d9bd3c2fd7c4e5a31", Name: "National Catfish Day"}
+	cincoDay   = holidays.EventSummary{Id: "b80630ae75c35f34c0526173dd999cfc", Name: "Cinco de Mayo"}
+)
+
+func TestHistory(t *testing.T) {
+	now := time.Date(2025, time.May, 5, 12, 0, 0, 0, time.UTC)
+
+	t.Run("remembers the most recent events first", func(t *testing.T) {
+		h := loadHistory("")
+
+		h.add(now, catDay, cincoDay)
+		h.add(now.Add(time.Hour), catDay)
+
+		assert.Equal(t, []historyEntry{
+			{Id: catDay.Id, Name: catDay.Name, Seen: now.Add(time.Hour)},
+			{Id: cincoDay.Id, Name: cincoDay.Name, Seen: now},
+		}, h.entries)
+	})
+
+	t.Run("forgets the oldest events", func(t *testing.T) {
+		h := loadHistory("")
+		for i := 0; i < historyMax+10; i++ {
+			h.add(now, holidays.EventSummary{Id: fmt.Sprintf("%032x", i), Name: "Event"})
+		}
+
+		assert.Equal(t, historyMax, len(h.entries))
+	})
+
+	t.Run("saves and loads", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "checkiday", "history.json")
+		h := loadHistory(path)
+		h.add(now, catDay)
+
+		assert.Nil(t, h.save())
+
+		assert.Equal(t, h.entries, loadHistory(path).entries)
+	})
+
+	t.Run("doesn't save unchanged histories", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.json")
+
+		assert.Nil(t, loadHistory(path).save())
+
+		_, err := os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("ignores unreadable files", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.json")
+		assert.Nil(t, os.WriteFile(path, []byte("nope"), 0o644))
+
+		assert.Equal(t, 0, len(loadHistory(path).entries))
+	})
+
+	t.Run("completes ids and name words", func(t *testing.T) {
+		h := loadHistory("")
+		h.add(now, cincoDay, catfishDay, catDay)
+
+		assert.Equal(t, []historyEntry{
+			{Id: catDay.Id, Name: catDay.Name, Seen: now},
+			{Id: catfishDay.Id, Name: catfishDay.Name, Seen: now},
+		}, h.complete("Cat"))
+		assert.Equal(t, cincoDay.Id, h.complete("de may")[0].Id)
+		assert.Equal(t, catfishDay.Id, h.complete("c6")[0].Id)
+		assert.Equal(t, 3, len(h.complete("")))
+	})
+
+	t.Run("resolves names to ids", func(t *testing.T) {
+		h := loadHistory("")
+		h.add(now, cincoDay, catfishDay, catDay)
+
+		for arg, want := range map[string]string{
+			"f90b893ea04939d7456f30c54f68d7b4":                                         catDay.Id,
+			"00000000000000000000000000000000":                                         "00000000000000000000000000000000",
+			"international cat day":                                                    catDay.Id,
+			"https://www.checkiday.com/b80630ae75c35f34c0526173dd999cfc/cinco-de-mayo": cincoDay.Id,
+			"Catfish": catfishDay.Id,
+			"cinco":   cincoDay.Id,
+		} {
+			id, err := h.resolve(arg)
+
+			assert.Nil(t, err, arg)
+			assert.Equal(t, want, id, arg)
+		}
+	})
+
+	t.Run("fails on ambiguous names", func(t *testing.T) {
+		h := loadHistory("")
+		h.add(now, catfishDay, catDay)
+
+		_, err := h.resolve("cat")
+
+		assert.EqualError(t, err, "\"cat\" matches several events: International Cat Day, National Catfish Day")
+	})
+
+	t.Run("fails on unknown names", func(t *testing.T) {
+		_, err := loadHistory("").resolve("Pie Day")
+
+		assert.EqualError(t, err, "no recently seen event matches \"Pie Day\"")
+	})
+}
+
+func TestRecordingClient(t *testing.T) {
+	t.Run("records seen events", func(t *testing.T) {
+		client := browserClient(t)
+		e, _, _ := testEnv(client)
+
+		code := run(e, []string{"cal"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, 5, len(e.history.entries))
+		assert.Equal(t, 1, len(e.history.complete("Cinco")))
+	})
+}
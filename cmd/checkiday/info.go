@@ -0,0 +1,45 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// The width info wraps text to
+const infoWidth = 80
+
+func runInfo(e env, args []string) error {
+	fs := e.flags("info")
+	start := fs.Int("start", 0, "The first year of occurrences to show. Defaults to 2 years ago.")
+	end := fs.Int("end", 0, "The last year of occurrences to show. Defaults to 3 years from now.")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("info needs an event id, URL or name")
+	}
+
+	id, err := e.history.resolve(strings.Join(fs.Args(), " "))
+	if err != nil {
+		return err
+	}
+
+	client, err := e.client()
+	if err != nil {
+		return err
+	}
+	res, err := client.GetEventInfo(holidays.GetEventInfoRequest{Id: id, Start: *start, End: *end})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(e.stdout, res.Event.Name)
+	fmt.Fprintln(e.stdout, res.Event.Url)
+	for _, line := range infoLines(res.Event, infoWidth) {
+		fmt.Fprintln(e.stdout, line)
+	}
+	return nil
+}
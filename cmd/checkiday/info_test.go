@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+func TestInfo(t *testing.T) {
+	t.Run("shows events by id", func(t *testing.T) {
+		client := browserClient(t)
+		e, stdout, _ := testEnv(client)
+
+		code := run(e, []string{"info", "--start", "2024", "--end", "2025", "b80630ae75c35f34c0526173dd999cfc"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, []any{holidays.GetEventInfoRequest{Id: "b80630ae75c35f34c0526173dd999cfc", Start: 2024, End: 2025}}, client.requests)
+		assert.Contains(t, stdout.String(), "International Cat Day\nhttps://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day\nDescription\n")
+		assert.Contains(t, stdout.String(), "\nFounders\n• International Fund For Animal Welfare (2002) https://www.ifaw.org/\n")
+	})
+
+	t.Run("shows events by recently seen name", func(t *testing.T) {
+		client := browserClient(t)
+		client.infos[catDay.Id] = client.infos["b80630ae75c35f34c0526173dd999cfc"]
+		e, stdout, _ := testEnv(client)
+		e.history.add(e.now(), catDay)
+
+		code := run(e, []string{"info", "International", "Cat"})
+
+		assert.Equal(t, 0, code)
+		assert.Equal(t, []any{holidays.GetEventInfoRequest{Id: catDay.Id}}, client.requests)
+		assert.Contains(t, stdout.String(), "International Cat Day\n")
+	})
+
+	t.Run("needs an event", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"info"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: info needs an event id, URL or name\n", stderr.String())
+	})
+
+	t.Run("fails on unknown names", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"info", "Cat"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: no recently seen event matches \"Cat\"\n", stderr.String())
+	})
+}
@@ -0,0 +1,160 @@
+// Command checkiday shows holidays and events from the Holiday and Event API in the terminal.
+//
+// It reads the API key from the CHECKIDAY_API_KEY environment variable.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// The environment variable holding the API key
+const apiKeyEnv = "CHECKIDAY_API_KEY"
+
+// The subset of *holidays.Client used by the commands
+type Client interface {
+	GetEvents(req holidays.GetEventsRequest) (*holidays.GetEventsResponse, error)
+	GetEventInfo(req holidays.GetEventInfoRequest) (*holidays.GetEventInfoResponse, error)
+	Search(req holidays.SearchRequest) (*holidays.SearchResponse, error)
+}
+
+// What a command runs with
+type env struct {
+	stdout    io.Writer
+	stderr    io.Writer
+	getenv    func(string) string
+	newClient func(apiKey string) (Client, error)
+	isTTY     bool
+	now       func() time.Time
+	history   *history
+}
+
+// A subcommand
+type command struct {
+	name    string
+	summary string
+	run     func(e env, args []string) error
+	hidden  bool // left out of the usage
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"cal", "Print a month calendar of holidays", runCal, false},
+		{"browse", "Browse events interactively", runBrowse, false},
+		{"info", "Show an event's details by id, URL or recently seen name", runInfo, false},
+		{"completion", "Print a bash, zsh or fish completion script", runCompletion, false},
+		{"__complete", "", runComplete, true},
+		{"__complete-names", "", runCompleteNames, true},
+	}
+}
+
+func main() {
+	stat, _ := os.Stdout.Stat()
+	historyPath := ""
+	if dir, err := os.UserCacheDir(); err == nil {
+		historyPath = filepath.Join(dir, "checkiday", "history.json")
+	}
+	e := env{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+		newClient: func(apiKey string) (Client, error) {
+			return holidays.New(apiKey)
+		},
+		isTTY:   stat != nil && stat.Mode()&os.ModeCharDevice != 0,
+		now:     time.Now,
+		history: loadHistory(historyPath),
+	}
+	os.Exit(run(e, os.Args[1:]))
+}
+
+// Runs the command named by args[0], returning the exit code
+func run(e env, args []string) int {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		usage(e.stderr)
+		return 2
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(e, args[1:])
+		if saveErr := e.history.save(); saveErr != nil {
+			fmt.Fprintln(e.stderr, "checkiday:", saveErr)
+		}
+		if errors.Is(err, flag.ErrHelp) || errors.Is(err, errUsage) {
+			return 2
+		}
+		if err != nil {
+			fmt.Fprintln(e.stderr, "checkiday:", err)
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(e.stderr, "checkiday: unknown command %q\n", args[0])
+	usage(e.stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: checkiday <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		if !cmd.hidden {
+			fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.summary)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "The API key is read from %s.\n", apiKeyEnv)
+}
+
+// Creates the API Client from the environment. It remembers the Events it sees in the history.
+func (e env) client() (Client, error) {
+	apiKey := e.getenv(apiKeyEnv)
+	if apiKey == "" {
+		return nil, fmt.Errorf("%s is not set", apiKeyEnv)
+	}
+	client, err := e.newClient(apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingClient{Client: client, history: e.history, now: e.now}, nil
+}
+
+// The error returned for invalid flags, which the FlagSet already reported
+var errUsage = errors.New("invalid usage")
+
+// Creates a FlagSet for a command that reports errors to stderr
+func (e env) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet("checkiday "+name, flag.ContinueOnError)
+	fs.SetOutput(e.stderr)
+	return fs
+}
+
+// Parses a command's flags
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	return nil
+}
+
+// Reports whether to write ANSI colors
+func (e env) color(noColor bool) bool {
+	return e.isTTY && !noColor && e.getenv("NO_COLOR") == ""
+}
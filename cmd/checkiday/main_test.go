@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// A Client serving canned responses
+type fakeClient struct {
+	mu       sync.Mutex
+	events   map[string]*holidays.GetEventsResponse // by Date
+	infos    map[string]*holidays.GetEventInfoResponse
+	searches map[string]*holidays.SearchResponse // by Query
+	err      error
+	requests []any
+}
+
+func (f *fakeClient) GetEvents(req holidays.GetEventsRequest) (*holidays.GetEventsResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if res, ok := f.events[req.Date]; ok {
+		return res, nil
+	}
+	return &holidays.GetEventsResponse{Date: req.Date}, nil
+}
+
+func (f *fakeClient) GetEventInfo(req holidays.GetEventInfoRequest) (*holidays.GetEventInfoResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if res, ok := f.infos[req.Id]; ok {
+		return res, nil
+	}
+	return nil, errors.New("Event not found.")
+}
+
+func (f *fakeClient) Search(req holidays.SearchRequest) (*holidays.SearchResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	if f.err != nil {
+		return nil, f.err
+	}
+	if res, ok := f.searches[req.Query]; ok {
+		return res, nil
+	}
+	return &holidays.SearchResponse{Query: req.Query}, nil
+}
+
+// Creates an env using client, capturing output
+func testEnv(client Client) (env, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	vars := map[string]string{apiKeyEnv: "abc123"}
+	return env{
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(name string) string { return vars[name] },
+		newClient: func(apiKey string) (Client, error) {
+			return client, nil
+		},
+		now:     func() time.Time { return time.Date(2025, time.May, 5, 12, 0, 0, 0, time.UTC) },
+		history: loadHistory(""),
+	}, &stdout, &stderr
+}
+
+func TestRun(t *testing.T) {
+	t.Run("prints usage without a command", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, nil)
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "Usage: checkiday <command> [flags]")
+		assert.Contains(t, stderr.String(), "cal")
+	})
+
+	t.Run("fails on unknown commands", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"nope"})
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "checkiday: unknown command \"nope\"")
+	})
+
+	t.Run("fails on invalid flags", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"cal", "--nope"})
+
+		assert.Equal(t, 2, code)
+		assert.Contains(t, stderr.String(), "flag provided but not defined: -nope")
+		assert.NotContains(t, stderr.String(), "checkiday:")
+	})
+
+	t.Run("fails without an API key", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+		e.getenv = func(string) string { return "" }
+
+		code := run(e, []string{"cal"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: CHECKIDAY_API_KEY is not set\n", stderr.String())
+	})
+
+	t.Run("reports client errors", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{err: errors.New("boom")})
+
+		code := run(e, []string{"cal"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: can't get events for 2025-05-01: boom\n", stderr.String())
+	})
+}
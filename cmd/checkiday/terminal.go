@@ -0,0 +1,198 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ANSI escape codes for full-screen drawing
+const (
+	ansiAltScreen  = "\x1b[?1049h"
+	ansiMainScreen = "\x1b[?1049l"
+	ansiHideCursor = "\x1b[?25l"
+	ansiShowCursor = "\x1b[?25h"
+	ansiClear      = "\x1b[H\x1b[2J"
+)
+
+func runBrowse(e env, args []string) error {
+	fs := e.flags("browse")
+	date := fs.String("date", "", "The date to start on, as YYYY-MM-DD. Defaults to today.")
+	timezone := fs.String("timezone", "", "IANA Time Zone for calculating dates. Defaults to America/Chicago.")
+	adult := fs.Bool("adult", false, "Include events that may be unsafe for viewing at work or by children")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	now := e.now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if *date != "" {
+		var err error
+		start, err = time.Parse(time.DateOnly, *date)
+		if err != nil {
+			return fmt.Errorf("can't parse date %q, expected YYYY-MM-DD", *date)
+		}
+	}
+
+	if !e.isTTY {
+		return errors.New("browse needs a terminal")
+	}
+	client, err := e.client()
+	if err != nil {
+		return err
+	}
+
+	restore, err := rawMode()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	width, height := terminalSize()
+	fmt.Fprint(e.stdout, ansiAltScreen+ansiHideCursor)
+	defer fmt.Fprint(e.stdout, ansiShowCursor+ansiMainScreen)
+
+	b := newBrowser(client, start, *timezone, *adult, e.now)
+	browse(b, readKeys(os.Stdin), func(b *browser) {
+		fmt.Fprint(e.stdout, ansiClear+strings.Join(b.view(width, height), "\r\n"))
+	})
+	return nil
+}
+
+// Runs the browser until it quits or keys closes, drawing after every change
+func browse(b *browser, keys <-chan key, draw func(*browser)) {
+	draw(b)
+	for !b.quit {
+		var timer <-chan time.Time
+		if at := b.nextTick(); !at.IsZero() {
+			timer = time.After(at.Sub(b.now()))
+		}
+
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				return
+			}
+			b.handle(k)
+		case <-timer:
+			b.tick()
+		}
+		draw(b)
+	}
+}
+
+// Reads key presses from r until it fails
+func readKeys(r io.Reader) <-chan key {
+	keys := make(chan key)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 256)
+		for {
+			n, err := r.Read(buf)
+			for _, k := range decodeKeys(buf[:n]) {
+				keys <- k
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return keys
+}
+
+// Decodes the bytes a terminal sends in raw mode into key presses
+func decodeKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		switch c := b[0]; {
+		case c == 0x1b:
+			if len(b) == 1 || b[1] != '[' && b[1] != 'O' {
+				keys = append(keys, keyEsc)
+				b = b[1:]
+				continue
+			}
+			// a control sequence ends with a byte in 0x40-0x7e
+			end := 2
+			for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+				end++
+			}
+			if end < len(b) {
+				switch b[end] {
+				case 'A':
+					keys = append(keys, keyUp)
+				case 'B':
+					keys = append(keys, keyDown)
+				case 'C':
+					keys = append(keys, keyRight)
+				case 'D':
+					keys = append(keys, keyLeft)
+				}
+				end++
+			}
+			b = b[end:]
+		case c == 0x03:
+			keys = append(keys, keyCtrlC)
+			b = b[1:]
+		case c == '\r' || c == '\n':
+			keys = append(keys, keyEnter)
+			b = b[1:]
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, keyBackspace)
+			b = b[1:]
+		case c < 0x20:
+			b = b[1:]
+		default:
+			r, size := utf8.DecodeRune(b)
+			if r != utf8.RuneError {
+				keys = append(keys, key(string(r)))
+			}
+			b = b[size:]
+		}
+	}
+	return keys
+}
+
+// Puts the terminal into raw mode, returning a func that restores it
+func rawMode() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("can't read terminal settings: %w", err)
+	}
+	if _, err := stty("raw", "-echo"); err != nil {
+		return nil, fmt.Errorf("can't enable raw mode: %w", err)
+	}
+	return func() {
+		stty(strings.TrimSpace(saved))
+	}, nil
+}
+
+// Gets the terminal width and height, defaulting to 80x24
+func terminalSize() (int, int) {
+	out, err := stty("size")
+	if err != nil {
+		return 80, 24
+	}
+	fields := strings.Fields(out)
+	if len(fields) != 2 {
+		return 80, 24
+	}
+	height, err1 := strconv.Atoi(fields[0])
+	width, err2 := strconv.Atoi(fields[1])
+	if err1 != nil || err2 != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	t.Run("decodes arrows", func(t *testing.T) {
+		assert.Equal(t, []key{keyUp, keyDown, keyRight, keyLeft, keyUp}, decodeKeys([]byte("\x1b[A\x1b[B\x1b[C\x1b[D\x1bOA")))
+	})
+
+	t.Run("decodes control keys", func(t *testing.T) {
+		assert.Equal(t, []key{keyEnter, keyEnter, keyBackspace, keyBackspace, keyCtrlC, keyEsc}, decodeKeys([]byte("\r\n\x7f\x08\x03\x1b")))
+	})
+
+	t.Run("decodes text", func(t *testing.T) {
+		assert.Equal(t, []key{"c", "a", "f", "é", " "}, decodeKeys([]byte("café ")))
+	})
+
+	t.Run("skips unknown sequences", func(t *testing.T) {
+		assert.Equal(t, []key{"a", "b"}, decodeKeys([]byte("a\x1b[5~\x01b")))
+	})
+}
+
+func TestBrowse(t *testing.T) {
+	t.Run("draws until quit", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+		keys := make(chan key, 3)
+		keys <- keyDown
+		keys <- keyRight
+		keys <- "q"
+		var draws []string
+
+		browse(b, keys, func(b *browser) {
+			draws = append(draws, b.view(80, 24)[0])
+		})
+
+		assert.Equal(t, []string{"Monday, May 5, 2025", "Monday, May 5, 2025", "Tuesday, May 6, 2025", "Tuesday, May 6, 2025"}, draws)
+	})
+
+	t.Run("stops when keys close", func(t *testing.T) {
+		b, _ := newTestBrowser(browserClient(t))
+		keys := make(chan key)
+		close(keys)
+
+		browse(b, keys, func(*browser) {})
+
+		assert.False(t, b.quit)
+	})
+
+	t.Run("searches after the delay", func(t *testing.T) {
+		client := browserClient(t)
+		b := newBrowser(client, time.Date(2025, time.May, 5, 0, 0, 0, 0, time.UTC), "", false, time.Now)
+		keys := make(chan key)
+		done := make(chan struct{})
+		searched := make(chan struct{}, 1)
+		go func() {
+			defer close(done)
+			browse(b, keys, func(b *browser) {
+				if b.searched != "" {
+					select {
+					case searched <- struct{}{}:
+					default:
+					}
+				}
+			})
+		}()
+
+		for _, k := range []key{"/", "z", "u", "c", "c", "h", "i", "n", "i"} {
+			keys <- k
+		}
+		<-searched
+		keys <- keyCtrlC
+		<-done
+
+		assert.True(t, strings.Contains(strings.Join(b.view(80, 24), "\n"), "National Zucchini Day"))
+	})
+}
+
+func TestRunBrowse(t *testing.T) {
+	t.Run("needs a terminal", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"browse"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: browse needs a terminal\n", stderr.String())
+	})
+
+	t.Run("fails on invalid dates", func(t *testing.T) {
+		e, _, stderr := testEnv(&fakeClient{})
+
+		code := run(e, []string{"browse", "--date", "05/05/2025"})
+
+		assert.Equal(t, 1, code)
+		assert.Equal(t, "checkiday: can't parse date \"05/05/2025\", expected YYYY-MM-DD\n", stderr.String())
+	})
+}
@@ -0,0 +1,201 @@
+package holidays
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// How a Client decodes API responses
+type DecodeMode int
+
+const (
+	DecodeDefault DecodeMode = iota // Ignore unknown fields and don't check for missing fields
+	DecodeStrict                    // Fail with a *SchemaError when fields are unknown or required fields are missing
+	DecodeLenient                   // Collect unknown fields into each response's Extra map
+)
+
+// Sets how the Client decodes API responses, to detect upstream schema changes. Defaults to DecodeDefault.
+func WithDecodeMode(mode DecodeMode) Option {
+	return func(c *Client) {
+		c.decodeMode = mode
+	}
+}
+
+// Reports differences between a response and the schema the Client expects
+type SchemaError struct {
+	Unknown []string // The paths of fields in the response that the Client doesn't know, e.g. "event.new_field"
+	Missing []string // The paths of required fields missing from the response, e.g. "events[].id"
+}
+
+func (e *SchemaError) Error() string {
+	var problems []string
+	if len(e.Unknown) > 0 {
+		problems = append(problems, "unknown fields: "+strings.Join(e.Unknown, ", "))
+	}
+	if len(e.Missing) > 0 {
+		problems = append(problems, "missing fields: "+strings.Join(e.Missing, ", "))
+	}
+	return "unexpected response schema: " + strings.Join(problems, "; ")
+}
+
+// The fields a GetEvents response must have
+func (GetEventsResponse) requiredFields() []string {
+	return []string{"adult", "date", "timezone", "events", "events[].id", "events[].name", "events[].url", "multiday_starting", "multiday_ongoing"}
+}
+
+// The fields a Search response must have
+func (SearchResponse) requiredFields() []string {
+	return []string{"query", "adult", "events", "events[].id", "events[].name", "events[].url"}
+}
+
+// The fields a GetEventInfo response must have
+func (GetEventInfoResponse) requiredFields() []string {
+	return []string{"event", "event.id", "event.name", "event.url"}
+}
+
+// Decodes body into result according to mode
+func decode(mode DecodeMode, body io.Reader, result any) error {
+	if mode == DecodeDefault {
+		return json.NewDecoder(body).Decode(result)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return err
+	}
+
+	unknown := map[string]json.RawMessage{}
+	unknownFields(data, reflect.TypeOf(result), "", unknown)
+
+	switch mode {
+	case DecodeLenient:
+		if s, ok := result.(interface{ standard() *StandardResponse }); ok && len(unknown) > 0 {
+			s.standard().Extra = unknown
+		}
+	case DecodeStrict:
+		schemaErr := &SchemaError{}
+		for path := range unknown {
+			schemaErr.Unknown = append(schemaErr.Unknown, path)
+		}
+		sort.Strings(schemaErr.Unknown)
+		if r, ok := result.(interface{ requiredFields() []string }); ok {
+			for _, path := range r.requiredFields() {
+				if !hasField(data, strings.Split(path, ".")) {
+					schemaErr.Missing = append(schemaErr.Missing, path)
+				}
+			}
+		}
+		if len(schemaErr.Unknown) > 0 || len(schemaErr.Missing) > 0 {
+			return schemaErr
+		}
+	}
+
+	return nil
+}
+
+// Collects the fields in data that t doesn't declare, keyed by path
+func unknownFields(data json.RawMessage, t reflect.Type, path string, unknown map[string]json.RawMessage) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var object map[string]json.RawMessage
+		if json.Unmarshal(data, &object) != nil {
+			return
+		}
+		known := knownFields(t)
+		for key, value := range object {
+			fieldPath := key
+			if path != "" {
+				fieldPath = path + "." + key
+			}
+			// encoding/json matches keys case-insensitively
+			fieldType, ok := known[strings.ToLower(key)]
+			if !ok {
+				if _, seen := unknown[fieldPath]; !seen {
+					unknown[fieldPath] = value
+				}
+				continue
+			}
+			unknownFields(value, fieldType, fieldPath, unknown)
+		}
+	case reflect.Slice, reflect.Array:
+		var elements []json.RawMessage
+		if json.Unmarshal(data, &elements) != nil {
+			return
+		}
+		for _, element := range elements {
+			unknownFields(element, t.Elem(), path+"[]", unknown)
+		}
+	}
+}
+
+// Gets the JSON keys (lowercased) a struct type decodes and their types, including promoted fields
+func knownFields(t reflect.Type) map[string]reflect.Type {
+	known := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for key, fieldType := range knownFields(field.Type) {
+				known[key] = fieldType
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = field.Type
+	}
+	return known
+}
+
+// Reports whether data has a non-null field at path. A segment ending in "[]" requires the path in every element.
+func hasField(data json.RawMessage, path []string) bool {
+	if len(path) == 0 {
+		return true
+	}
+
+	var object map[string]json.RawMessage
+	if json.Unmarshal(data, &object) != nil {
+		return false
+	}
+
+	key, each := strings.CutSuffix(path[0], "[]")
+	value, ok := object[key]
+	if !ok || string(value) == "null" {
+		return false
+	}
+	if !each {
+		return hasField(value, path[1:])
+	}
+
+	var elements []json.RawMessage
+	if json.Unmarshal(value, &elements) != nil {
+		return false
+	}
+	for _, element := range elements {
+		if !hasField(element, path[1:]) {
+			return false
+		}
+	}
+	return true
+}
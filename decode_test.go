@@ -0,0 +1,128 @@
+package holidays
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeMode(t *testing.T) {
+	t.Run("strict mode accepts the current schema", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			Reply(200).
+			File("testdata/search-default.json")
+
+		api, _ := New("abc123", WithDecodeMode(DecodeStrict))
+		_, err := api.GetEvents(GetEventsRequest{})
+		assert.Nil(t, err)
+		_, err = api.GetEventInfo(GetEventInfoRequest{Id: "f90b893ea04939d7456f30c54f68d7b4"})
+		assert.Nil(t, err)
+		_, err = api.Search(SearchRequest{Query: "zucchini"})
+		assert.Nil(t, err)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("strict mode reports unknown and missing fields", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			BodyString(`{
+				"adult": false,
+				"date": "05/05/2025",
+				"events": [{"id": "abc", "name": "Cinco de Mayo", "emoji": "🎉"}],
+				"multiday_starting": [],
+				"multiday_ongoing": [],
+				"region": "US"
+			}`)
+
+		api, _ := New("abc123", WithDecodeMode(DecodeStrict))
+		response, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "can't parse response: unexpected response schema: unknown fields: events[].emoji, region; missing fields: timezone, events[].url")
+		var schemaErr *SchemaError
+		assert.ErrorAs(t, err, &schemaErr)
+		assert.Equal(t, []string{"timezone", "events[].url"}, schemaErr.Missing)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("strict mode treats null as missing", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			Reply(200).
+			BodyString(`{"event": null}`)
+
+		api, _ := New("abc123", WithDecodeMode(DecodeStrict))
+		_, err := api.GetEventInfo(GetEventInfoRequest{Id: "hi"})
+
+		assert.EqualError(t, err, "can't parse response: unexpected response schema: missing fields: event, event.id, event.name, event.url")
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("lenient mode collects unknown fields", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			Reply(200).
+			BodyString(`{
+				"event": {
+					"id": "abc",
+					"name": "International Cat Day",
+					"patterns": [{"first_year": 2002, "last_year": null, "weekday": "Friday"}]
+				},
+				"region": "US"
+			}`)
+
+		api, _ := New("abc123", WithDecodeMode(DecodeLenient))
+		response, err := api.GetEventInfo(GetEventInfoRequest{Id: "abc"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "International Cat Day", response.Event.Name)
+		assert.Equal(t, map[string]json.RawMessage{
+			"event.patterns[].weekday": json.RawMessage(`"Friday"`),
+			"region":                   json.RawMessage(`"US"`),
+		}, response.Extra)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("default mode ignores unknown fields", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			Reply(200).
+			BodyString(`{"query": "zucchini", "region": "US"}`)
+
+		api, _ := New("abc123")
+		response, err := api.Search(SearchRequest{Query: "zucchini"})
+
+		assert.Nil(t, err)
+		assert.Nil(t, response.Extra)
+
+		assert.True(t, gock.IsDone())
+	})
+}
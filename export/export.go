@@ -0,0 +1,204 @@
+// Package export streams Events to CSV and newline-delimited JSON for spreadsheets and data loaders.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// A CSV column
+type Column[T any] struct {
+	Name  string         // The header name
+	Value func(T) string // Gets the column's value for a record
+}
+
+// A flattened Event Occurrence, one row per Occurrence
+type OccurrenceRow struct {
+	EventId string `json:"event_id"` // The Event Id
+	Name    string `json:"name"`     // The name the Event had on the Occurrence's date
+	Url     string `json:"url"`      // The Event URL
+	Adult   bool   `json:"adult"`    // Whether the Event is unsafe for children or viewing at work
+	Date    string `json:"date"`     // The Occurrence date as YYYY-MM-DD, or as returned by the API if it can't be parsed
+	Length  int    `json:"length"`   // The length (in days) of the Occurrence
+}
+
+// The columns of an Event Summary
+var EventSummaryColumns = []Column[holidays.EventSummary]{
+	{"id", func(e holidays.EventSummary) string { return e.Id }},
+	{"name", func(e holidays.EventSummary) string { return e.Name }},
+	{"url", func(e holidays.EventSummary) string { return e.Url }},
+}
+
+// The columns of an Event Info. List fields are joined with "; ".
+var EventInfoColumns = []Column[holidays.EventInfo]{
+	{"id", func(e holidays.EventInfo) string { return e.Id }},
+	{"name", func(e holidays.EventInfo) string { return e.Name }},
+	{"url", func(e holidays.EventInfo) string { return e.Url }},
+	{"adult", func(e holidays.EventInfo) string { return strconv.FormatBool(e.Adult) }},
+	{"alternate_names", func(e holidays.EventInfo) string {
+		names := make([]string, len(e.AlternateNames))
+		for i, name := range e.AlternateNames {
+			names[i] = name.Name
+		}
+		return join(names)
+	}},
+	{"hashtags", func(e holidays.EventInfo) string { return join(e.Hashtags) }},
+	{"image", func(e holidays.EventInfo) string { return e.Image.Medium }},
+	{"sources", func(e holidays.EventInfo) string { return join(e.Sources) }},
+	{"description", func(e holidays.EventInfo) string { return text(e.Description.Text) }},
+	{"how_to_observe", func(e holidays.EventInfo) string { return text(e.HowToObserve.Text) }},
+}
+
+// The columns of an Occurrence Row
+var OccurrenceColumns = []Column[OccurrenceRow]{
+	{"event_id", func(o OccurrenceRow) string { return o.EventId }},
+	{"name", func(o OccurrenceRow) string { return o.Name }},
+	{"url", func(o OccurrenceRow) string { return o.Url }},
+	{"adult", func(o OccurrenceRow) string { return strconv.FormatBool(o.Adult) }},
+	{"date", func(o OccurrenceRow) string { return o.Date }},
+	{"length", func(o OccurrenceRow) string { return strconv.Itoa(o.Length) }},
+}
+
+// Picks columns by name, in the given order
+func Select[T any](columns []Column[T], names ...string) ([]Column[T], error) {
+	selected := make([]Column[T], 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, column := range columns {
+			if column.Name == name {
+				selected = append(selected, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// Flattens an Event's Occurrences into rows
+func Occurrences(event holidays.EventInfo) []OccurrenceRow {
+	rows := make([]OccurrenceRow, len(event.Occurrences))
+	for i, occurrence := range event.Occurrences {
+		rows[i] = OccurrenceRow{
+			EventId: event.Id,
+			Name:    event.Name,
+			Url:     event.Url,
+			Adult:   event.Adult,
+			Date:    occurrence.Date,
+			Length:  occurrence.Length,
+		}
+		if date, err := occurrence.Time(); err == nil {
+			rows[i].Name = event.NameOn(date)
+			rows[i].Date = date.Format(time.DateOnly)
+		}
+	}
+	return rows
+}
+
+// Writes records as CSV rows, one at a time. The header is written before the first record.
+// Values that a spreadsheet would run as a formula are prefixed with a single quote.
+type CSVWriter[T any] struct {
+	w           *csv.Writer
+	columns     []Column[T]
+	wroteHeader bool
+}
+
+// Creates a CSVWriter with the given columns
+func NewCSV[T any](w io.Writer, columns []Column[T]) *CSVWriter[T] {
+	return &CSVWriter[T]{w: csv.NewWriter(w), columns: columns}
+}
+
+// Writes a record
+func (c *CSVWriter[T]) Write(record T) error {
+	if err := c.header(); err != nil {
+		return err
+	}
+
+	row := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		row[i] = escapeFormula(column.Value(record))
+	}
+	if err := c.w.Write(row); err != nil {
+		return fmt.Errorf("can't write CSV: %w", err)
+	}
+	return nil
+}
+
+// Writes any buffered rows, and the header if no records were written
+func (c *CSVWriter[T]) Flush() error {
+	if err := c.header(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("can't write CSV: %w", err)
+	}
+	return nil
+}
+
+func (c *CSVWriter[T]) header() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+
+	names := make([]string, len(c.columns))
+	for i, column := range c.columns {
+		names[i] = column.Name
+	}
+	if err := c.w.Write(names); err != nil {
+		return fmt.Errorf("can't write CSV: %w", err)
+	}
+	return nil
+}
+
+// Writes records as newline-delimited JSON, one line per record
+type NDJSONWriter[T any] struct {
+	encoder *json.Encoder
+}
+
+// Creates an NDJSONWriter
+func NewNDJSON[T any](w io.Writer) *NDJSONWriter[T] {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	return &NDJSONWriter[T]{encoder: encoder}
+}
+
+// Writes a record
+func (n *NDJSONWriter[T]) Write(record T) error {
+	if err := n.encoder.Encode(record); err != nil {
+		return fmt.Errorf("can't write NDJSON: %w", err)
+	}
+	return nil
+}
+
+func join(values []string) string {
+	return strings.Join(values, "; ")
+}
+
+// Normalizes line endings, so multi-line text is quoted consistently
+func text(s string) string {
+	return strings.ReplaceAll(strings.TrimSpace(s), "\r\n", "\n")
+}
+
+// Prefixes values starting like a spreadsheet formula with a single quote, so Excel and Sheets show them as text.
+// Numbers such as -1 are left alone.
+func escapeFormula(value string) string {
+	if value == "" || !strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + value
+}
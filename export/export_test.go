@@ -0,0 +1,167 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+func TestCSV(t *testing.T) {
+	t.Run("writes event summaries", func(t *testing.T) {
+		events := testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json").Events
+		var b bytes.Buffer
+		w := NewCSV(&b, EventSummaryColumns)
+
+		for _, event := range events[:2] {
+			assert.Nil(t, w.Write(event))
+		}
+		assert.Nil(t, w.Flush())
+
+		assert.Equal(t, "id,name,url\n"+
+			events[0].Id+","+events[0].Name+","+events[0].Url+"\n"+
+			events[1].Id+","+events[1].Name+","+events[1].Url+"\n", b.String())
+	})
+
+	t.Run("escapes description text", func(t *testing.T) {
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Id: "abc", Name: "Say \"Cheese\" Day"},
+			Description:  holidays.RichText{Text: "First line, with a comma.\r\nSecond \"quoted\" line."},
+			Hashtags:     []string{"Cheese", "SayCheese"},
+		}
+		var b bytes.Buffer
+		columns, err := Select(EventInfoColumns, "name", "hashtags", "description")
+		assert.Nil(t, err)
+		w := NewCSV(&b, columns)
+
+		assert.Nil(t, w.Write(event))
+		assert.Nil(t, w.Flush())
+
+		assert.Equal(t, "name,hashtags,description\n"+
+			"\"Say \"\"Cheese\"\" Day\",Cheese; SayCheese,\"First line, with a comma.\nSecond \"\"quoted\"\" line.\"\n", b.String())
+
+		rows, err := csv.NewReader(&b).ReadAll()
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"Say \"Cheese\" Day", "Cheese; SayCheese", "First line, with a comma.\nSecond \"quoted\" line."}, rows[1])
+	})
+
+	t.Run("escapes formulas", func(t *testing.T) {
+		var b bytes.Buffer
+		columns, err := Select(EventInfoColumns, "name", "description")
+		assert.Nil(t, err)
+		w := NewCSV(&b, columns)
+
+		for _, text := range []string{"=HYPERLINK(\"https://example.com\")", "+1+1", "-2+3", "@SUM(A1)", "\tTab", "-1.5", "Plain"} {
+			assert.Nil(t, w.Write(holidays.EventInfo{
+				EventSummary: holidays.EventSummary{Name: text},
+				Description:  holidays.RichText{Text: "Fine"},
+			}))
+		}
+		assert.Nil(t, w.Flush())
+
+		rows, err := csv.NewReader(&b).ReadAll()
+		assert.Nil(t, err)
+		var names []string
+		for _, row := range rows[1:] {
+			names = append(names, row[0])
+		}
+		assert.Equal(t, []string{"'=HYPERLINK(\"https://example.com\")", "'+1+1", "'-2+3", "'@SUM(A1)", "'\tTab", "-1.5", "Plain"}, names)
+	})
+
+	t.Run("writes the header without records", func(t *testing.T) {
+		var b bytes.Buffer
+		w := NewCSV(&b, OccurrenceColumns)
+
+		assert.Nil(t, w.Flush())
+
+		assert.Equal(t, "event_id,name,url,adult,date,length\n", b.String())
+	})
+
+	t.Run("writes occurrences", func(t *testing.T) {
+		event := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json").Event
+		var b bytes.Buffer
+		w := NewCSV(&b, OccurrenceColumns)
+
+		for _, row := range Occurrences(event) {
+			assert.Nil(t, w.Write(row))
+		}
+		assert.Nil(t, w.Flush())
+
+		lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+		assert.Equal(t, len(event.Occurrences)+1, len(lines))
+		assert.Equal(t, event.Id+","+event.Name+","+event.Url+",false,2020-08-08,1", lines[1])
+	})
+}
+
+func TestSelect(t *testing.T) {
+	t.Run("keeps the given order", func(t *testing.T) {
+		columns, err := Select(EventSummaryColumns, "url", "id")
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(columns))
+		assert.Equal(t, "url", columns[0].Name)
+		assert.Equal(t, "id", columns[1].Name)
+	})
+
+	t.Run("fails on unknown columns", func(t *testing.T) {
+		_, err := Select(EventSummaryColumns, "id", "nope")
+
+		assert.EqualError(t, err, "unknown column \"nope\"")
+	})
+}
+
+func TestOccurrences(t *testing.T) {
+	t.Run("flattens occurrences", func(t *testing.T) {
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Id: "abc", Name: "New Name", Url: "https://www.checkiday.com/abc/new-name"},
+			AlternateNames: []holidays.AlternateName{
+				{Name: "Old Name", FirstYear: holidays.Some(2000), LastYear: holidays.Some(2020)},
+			},
+			Occurrences: []holidays.Occurrence{
+				{Date: "08/08/2020", Length: 1},
+				{Date: "2021-08-08T00:00:00Z", Length: 2},
+				{Date: "sometime", Length: 1},
+			},
+		}
+
+		rows := Occurrences(event)
+
+		assert.Equal(t, []OccurrenceRow{
+			{EventId: "abc", Name: "Old Name", Url: event.Url, Date: "2020-08-08", Length: 1},
+			{EventId: "abc", Name: "New Name", Url: event.Url, Date: "2021-08-08", Length: 2},
+			{EventId: "abc", Name: "New Name", Url: event.Url, Date: "sometime", Length: 1},
+		}, rows)
+	})
+}
+
+func TestNDJSON(t *testing.T) {
+	t.Run("writes one line per record", func(t *testing.T) {
+		var b bytes.Buffer
+		w := NewNDJSON[OccurrenceRow](&b)
+
+		assert.Nil(t, w.Write(OccurrenceRow{EventId: "abc", Name: "Fish & Chips Day", Date: "2025-05-05", Length: 1}))
+		assert.Nil(t, w.Write(OccurrenceRow{EventId: "def", Name: "<Tag> Day", Date: "2025-05-06", Length: 2}))
+
+		assert.Equal(t,
+			`{"event_id":"abc","name":"Fish & Chips Day","url":"","adult":false,"date":"2025-05-05","length":1}`+"\n"+
+				`{"event_id":"def","name":"<Tag> Day","url":"","adult":false,"date":"2025-05-06","length":2}`+"\n",
+			b.String())
+	})
+
+	t.Run("writes event info", func(t *testing.T) {
+		event := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json").Event
+		var b bytes.Buffer
+
+		assert.Nil(t, NewNDJSON[holidays.EventInfo](&b).Write(event))
+
+		var decoded holidays.EventInfo
+		assert.Nil(t, json.Unmarshal(b.Bytes(), &decoded))
+		assert.Equal(t, event, decoded)
+		assert.Equal(t, 1, strings.Count(b.String(), "\n"))
+	})
+}
@@ -0,0 +1,271 @@
+// Package feed turns daily GetEvents responses into RSS 2.0 and Atom 1.0 feeds.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// The length of item descriptions built from EventInfo
+const summaryLength = 300
+
+// Feed options
+type Options struct {
+	Title       string                        // The feed title. Defaults to "Holidays Today".
+	Link        string                        // The website the feed belongs to. Defaults to https://www.checkiday.com/.
+	FeedUrl     string                        // The feed's own URL, used as the Atom feed id and self link. Optional.
+	Description string                        // The feed description. Defaults to the title.
+	Details     map[string]holidays.EventInfo // Event Info by Event Id, adding descriptions and images to items. Optional.
+}
+
+// A feed item: one Event on one day
+type item struct {
+	event   holidays.EventSummary
+	day     time.Time
+	guid    string
+	summary string
+	image   string
+}
+
+// Writes an RSS 2.0 feed of the Events of each day, newest first
+func RSS(w io.Writer, days []*holidays.GetEventsResponse, opts Options) error {
+	opts = withDefaults(opts)
+	items, err := collect(days, opts)
+	if err != nil {
+		return err
+	}
+
+	doc := rss{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       opts.Title,
+			Link:        opts.Link,
+			Description: opts.Description,
+		},
+	}
+	if len(items) > 0 {
+		doc.Channel.LastBuildDate = items[0].day.Format(time.RFC1123Z)
+	}
+
+	for _, it := range items {
+		rssItem := rssItem{
+			Title:       it.event.Name,
+			Link:        it.event.Url,
+			Description: it.summary,
+			Guid:        rssGuid{IsPermaLink: "false", Value: it.guid},
+			PubDate:     it.day.Format(time.RFC1123Z),
+		}
+		if it.image != "" {
+			rssItem.Enclosure = &rssEnclosure{Url: it.image, Length: "0", Type: imageType(it.image)}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rssItem)
+	}
+
+	return encode(w, doc)
+}
+
+// Writes an Atom 1.0 feed of the Events of each day, newest first
+func Atom(w io.Writer, days []*holidays.GetEventsResponse, opts Options) error {
+	opts = withDefaults(opts)
+	items, err := collect(days, opts)
+	if err != nil {
+		return err
+	}
+
+	doc := atomFeed{
+		Xmlns:    "http://www.w3.org/2005/Atom",
+		Id:       opts.Link,
+		Title:    opts.Title,
+		Subtitle: opts.Description,
+		Links:    []atomLink{{Href: opts.Link}},
+	}
+	if opts.FeedUrl != "" {
+		doc.Id = opts.FeedUrl
+		doc.Links = append(doc.Links, atomLink{Rel: "self", Href: opts.FeedUrl})
+	}
+	if len(items) > 0 {
+		doc.Updated = items[0].day.Format(time.RFC3339)
+	} else {
+		doc.Updated = time.Unix(0, 0).UTC().Format(time.RFC3339)
+	}
+
+	for _, it := range items {
+		entry := atomEntry{
+			Id:        it.guid,
+			Title:     it.event.Name,
+			Links:     []atomLink{{Href: it.event.Url}},
+			Updated:   it.day.Format(time.RFC3339),
+			Published: it.day.Format(time.RFC3339),
+			Author:    &atomAuthor{Name: "Checkiday"},
+		}
+		if it.summary != "" {
+			entry.Summary = &atomText{Type: "text", Value: it.summary}
+		}
+		if it.image != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "enclosure", Href: it.image, Type: imageType(it.image)})
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return encode(w, doc)
+}
+
+func withDefaults(opts Options) Options {
+	if opts.Title == "" {
+		opts.Title = "Holidays Today"
+	}
+	if opts.Link == "" {
+		opts.Link = "https://www.checkiday.com/"
+	}
+	if opts.Description == "" {
+		opts.Description = opts.Title
+	}
+	return opts
+}
+
+// Gets the items of every day, newest day first, keeping the API's order within a day
+func collect(days []*holidays.GetEventsResponse, opts Options) ([]item, error) {
+	var items []item
+	for _, res := range days {
+		day, err := parseDay(res)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, event := range append(append([]holidays.EventSummary(nil), res.Events...), res.MultidayStarting...) {
+			it := item{
+				event: event,
+				day:   day,
+				// the same Event recurs every year, so the date keeps GUIDs unique and stable
+				guid: fmt.Sprintf("tag:checkiday.com,2011:%s/%s", event.Id, day.Format(time.DateOnly)),
+			}
+			if info, ok := opts.Details[event.Id]; ok {
+				it.summary = info.Description.Summary(summaryLength)
+				it.image = info.Image.Medium
+			}
+			items = append(items, it)
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].day.After(items[j].day)
+	})
+
+	return items, nil
+}
+
+// Gets the start of a response's Date in its Timezone
+func parseDay(res *holidays.GetEventsResponse) (time.Time, error) {
+	loc := time.UTC
+	if res.Timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(res.Timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("can't load timezone %q: %w", res.Timezone, err)
+		}
+	}
+
+	day, err := time.ParseInLocation(holidays.DateLayout, res.Date, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't parse date %q: %w", res.Date, err)
+	}
+	return day, nil
+}
+
+// Guesses an image's content type from its URL
+func imageType(url string) string {
+	if t := mime.TypeByExtension(path.Ext(url)); t != "" {
+		return t
+	}
+	return "image/jpeg"
+}
+
+func encode(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("can't encode feed: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type rss struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link"`
+	Description string        `xml:"description,omitempty"`
+	Guid        rssGuid       `xml:"guid"`
+	PubDate     string        `xml:"pubDate"`
+	Enclosure   *rssEnclosure `xml:"enclosure"`
+}
+
+type rssGuid struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	Url    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Id       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Id        string      `xml:"id"`
+	Title     string      `xml:"title"`
+	Links     []atomLink  `xml:"link"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published"`
+	Author    *atomAuthor `xml:"author"`
+	Summary   *atomText   `xml:"summary"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Href string `xml:"href,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
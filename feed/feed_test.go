@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+func fixtures(t *testing.T) ([]*holidays.GetEventsResponse, Options) {
+	days := []*holidays.GetEventsResponse{
+		testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-parameters.json"),
+		testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json"),
+	}
+	info := holidays.EventInfo{
+		EventSummary: days[1].Events[0],
+		Description: holidays.RichText{
+			Text: "Cinco de Mayo commemorates the Mexican army's victory over the French at the Battle of Puebla in 1862. " +
+				"It is celebrated with parades, music and food.",
+		},
+		Image: holidays.ImageInfo{Medium: "https://static.checkiday.com/img/600/cinco-de-mayo.jpg"},
+	}
+
+	return days, Options{
+		FeedUrl: "https://example.com/holidays.xml",
+		Details: map[string]holidays.EventInfo{info.Id: info},
+	}
+}
+
+func TestRSS(t *testing.T) {
+	t.Run("renders days", func(t *testing.T) {
+		days, opts := fixtures(t)
+		var b bytes.Buffer
+
+		err := RSS(&b, days, opts)
+
+		assert.Nil(t, err)
+		assert.Nil(t, xml.Unmarshal(b.Bytes(), new(rss)))
+		testutil.AssertGolden(t, "rss.golden.xml", b.Bytes())
+	})
+
+	t.Run("fails on invalid timezones", func(t *testing.T) {
+		err := RSS(&bytes.Buffer{}, []*holidays.GetEventsResponse{{Date: "05/05/2025", Timezone: "Mars/Olympus"}}, Options{})
+
+		assert.EqualError(t, err, "can't load timezone \"Mars/Olympus\": unknown time zone Mars/Olympus")
+	})
+}
+
+func TestAtom(t *testing.T) {
+	t.Run("renders days", func(t *testing.T) {
+		days, opts := fixtures(t)
+		var b bytes.Buffer
+
+		err := Atom(&b, days, opts)
+
+		assert.Nil(t, err)
+		assert.Nil(t, xml.Unmarshal(b.Bytes(), new(atomFeed)))
+		testutil.AssertGolden(t, "atom.golden.xml", b.Bytes())
+	})
+
+	t.Run("renders empty feeds", func(t *testing.T) {
+		var b bytes.Buffer
+
+		err := Atom(&b, nil, Options{Title: "Nothing"})
+
+		assert.Nil(t, err)
+		assert.Contains(t, b.String(), "<updated>1970-01-01T00:00:00Z</updated>")
+	})
+}
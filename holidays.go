@@ -1,19 +1,55 @@
 package holidays
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"runtime"
 	"strconv"
+	"time"
 )
 
 // The API Client
 type Client struct {
-	apiKey string
+	apiKey     string
+	metrics    *Metrics
+	middleware []Middleware
+	doer       Doer
+	defaults   Defaults
+	decodeMode DecodeMode
+	keepRaw    bool
+}
+
+// Configures optional Client behavior
+type Option func(*Client)
+
+// Default request parameters that every request inherits unless it sets its own
+type Defaults struct {
+	Timezone string // Default Timezone for GetEvents
+	Adult    bool   // Default Adult for GetEvents and Search. Override it per call with ExcludeAdult.
+	Start    int    // Default Start of the GetEventInfo occurrence range
+	End      int    // Default End of the GetEventInfo occurrence range
+}
+
+// Sets the Client's default request parameters
+func WithDefaults(defaults Defaults) Option {
+	return func(c *Client) {
+		c.defaults = defaults
+	}
+}
+
+// Keeps the raw HTTP response in each response's Raw field, for auditing and debugging.
+// Response bodies are only buffered when this is set.
+func WithRawResponse() Option {
+	return func(c *Client) {
+		c.keepRaw = true
+	}
 }
 
 const (
@@ -22,24 +58,48 @@ const (
 	baseUrl   = "https://api.apilayer.com/checkiday/"
 )
 
+// The headers upstream services may identify requests with, in order of preference
+var requestIdHeaders = []string{"X-Request-Id", "X-Amzn-RequestId", "Request-Id"}
+
 // Creates a New Client using the provided API key.
 // Get a FREE API key from https://apilayer.com/marketplace/checkiday-api#pricing
-func New(apiKey string) (*Client, error) {
+func New(apiKey string, opts ...Option) (*Client, error) {
 	if apiKey == "" {
 		return nil, errors.New("please provide a valid API key. Get one at https://apilayer.com/marketplace/checkiday-api#pricing")
 	}
 
-	return &Client{
-		apiKey: apiKey,
-	}, nil
+	client := &Client{
+		apiKey:  apiKey,
+		metrics: newMetrics(),
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	client.doer = chain(http.DefaultClient, client.middleware)
+
+	return client, nil
 }
 
 // Gets the Events for the provided Date
 func (c *Client) GetEvents(req GetEventsRequest) (*GetEventsResponse, error) {
+	return c.getEvents(context.Background(), req)
+}
+
+func (c *Client) getEvents(ctx context.Context, req GetEventsRequest) (*GetEventsResponse, error) {
+	adult, err := c.adult(req.Adult, req.ExcludeAdult)
+	if err != nil {
+		return nil, err
+	}
+
 	var params = url.Values{
-		"adult": {strconv.FormatBool(req.Adult)},
+		"adult": {strconv.FormatBool(adult)},
 	}
 
+	if req.Timezone == "" {
+		req.Timezone = c.defaults.Timezone
+	}
 	if req.Timezone != "" {
 		params["timezone"] = []string{req.Timezone}
 	}
@@ -48,7 +108,7 @@ func (c *Client) GetEvents(req GetEventsRequest) (*GetEventsResponse, error) {
 		params["date"] = []string{req.Date}
 	}
 
-	res, rateLimit, err := request[GetEventsResponse](c, "events", params)
+	res, rateLimit, err := request[GetEventsResponse](ctx, c, "events", params)
 	if err != nil {
 		return nil, err
 	}
@@ -67,15 +127,21 @@ func (c *Client) GetEventInfo(req GetEventInfoRequest) (*GetEventInfoResponse, e
 	}
 	params["id"] = []string{req.Id}
 
+	if req.Start == 0 {
+		req.Start = c.defaults.Start
+	}
 	if req.Start != 0 {
 		params["start"] = []string{strconv.Itoa(req.Start)}
 	}
 
+	if req.End == 0 {
+		req.End = c.defaults.End
+	}
 	if req.End != 0 {
 		params["end"] = []string{strconv.Itoa(req.End)}
 	}
 
-	res, rateLimit, err := request[GetEventInfoResponse](c, "event", params)
+	res, rateLimit, err := request[GetEventInfoResponse](context.Background(), c, "event", params)
 	if err != nil {
 		return nil, err
 	}
@@ -87,8 +153,13 @@ func (c *Client) GetEventInfo(req GetEventInfoRequest) (*GetEventInfoResponse, e
 
 // Searches for Events with the given criteria
 func (c *Client) Search(req SearchRequest) (*SearchResponse, error) {
+	adult, err := c.adult(req.Adult, req.ExcludeAdult)
+	if err != nil {
+		return nil, err
+	}
+
 	var params = url.Values{
-		"adult": {strconv.FormatBool(req.Adult)},
+		"adult": {strconv.FormatBool(adult)},
 	}
 
 	if req.Query == "" {
@@ -96,7 +167,7 @@ func (c *Client) Search(req SearchRequest) (*SearchResponse, error) {
 	}
 	params["query"] = []string{req.Query}
 
-	res, rateLimit, err := request[SearchResponse](c, "search", params)
+	res, rateLimit, err := request[SearchResponse](context.Background(), c, "search", params)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +182,23 @@ func (c *Client) GetVersion() string {
 	return version
 }
 
-func request[R StandardResponseInterface](client *Client, urlPath string, params url.Values) (*R, *RateLimit, error) {
+// Resolves a request's Adult parameter against the Client's default
+func (c *Client) adult(adult, excludeAdult bool) (bool, error) {
+	if adult && excludeAdult {
+		return false, errors.New("adult and exclude adult can't both be set")
+	}
+	if excludeAdult {
+		return false, nil
+	}
+	return adult || c.defaults.Adult, nil
+}
+
+// Gets the Client's health and quota Metrics
+func (c *Client) Metrics() *Metrics {
+	return c.metrics
+}
+
+func request[R StandardResponseInterface](ctx context.Context, client *Client, urlPath string, params url.Values) (*R, *RateLimit, error) {
 	url, err := url.Parse(baseUrl)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't parse baseUrl: %w", err)
@@ -122,7 +209,7 @@ func request[R StandardResponseInterface](client *Client, urlPath string, params
 		url.RawQuery = params.Encode()
 	}
 
-	req, err := http.NewRequest("GET", url.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url.String(), nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("can't create request: %w", err)
 	}
@@ -131,10 +218,14 @@ func request[R StandardResponseInterface](client *Client, urlPath string, params
 	req.Header.Set("User-Agent", userAgent)
 	req.Header.Set("X-Platform-Version", runtime.Version())
 
-	res, err := http.DefaultClient.Do(req)
+	start := time.Now()
+	res, err := client.doer.Do(req)
 	if err != nil {
+		client.metrics.observe(urlPath, 0, time.Since(start))
 		return nil, nil, fmt.Errorf("can't process request: %w", err)
 	}
+	latency := time.Since(start)
+	client.metrics.observe(urlPath, res.StatusCode, latency)
 
 	defer res.Body.Close()
 	if res.StatusCode != http.StatusOK {
@@ -145,10 +236,30 @@ func request[R StandardResponseInterface](client *Client, urlPath string, params
 		return nil, nil, errors.New(res.Status)
 	}
 
+	var body io.Reader = res.Body
+	var raw *RawResponse
+	if client.keepRaw {
+		rawBody, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't read response: %w", err)
+		}
+		body = bytes.NewReader(rawBody)
+		raw = &RawResponse{
+			StatusCode: res.StatusCode,
+			Header:     res.Header.Clone(),
+			Body:       rawBody,
+			Url:        redactURL(req.URL, redactedNames).String(),
+		}
+	}
+
 	var result R
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+	if err := decode(client.decodeMode, body, &result); err != nil {
 		return nil, nil, fmt.Errorf("can't parse response: %w", err)
 	}
+	if s, ok := any(&result).(interface{ standard() *StandardResponse }); ok {
+		s.standard().Raw = raw
+		s.standard().Metadata = metadata(res, latency)
+	}
 
 	limitMonth, _ := strconv.Atoi(res.Header.Get("x-ratelimit-limit-month"))
 	remainingMonth, _ := strconv.Atoi(res.Header.Get("x-ratelimit-remaining-month"))
@@ -156,6 +267,27 @@ func request[R StandardResponseInterface](client *Client, urlPath string, params
 		LimitMonth:     limitMonth,
 		RemainingMonth: remainingMonth,
 	}
+	client.metrics.observeRateLimit(rateLimit)
 
 	return &result, &rateLimit, nil
 }
+
+// Gathers a response's Metadata
+func metadata(res *http.Response, latency time.Duration) ResponseMetadata {
+	metadata := ResponseMetadata{
+		Latency: latency,
+	}
+
+	for _, header := range requestIdHeaders {
+		if id := res.Header.Get(header); id != "" {
+			metadata.RequestId = id
+			break
+		}
+	}
+
+	if res.Request != nil && res.Request.URL != nil {
+		metadata.FinalUrl = redactURL(res.Request.URL, redactedNames).String()
+	}
+
+	return metadata
+}
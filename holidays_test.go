@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/h2non/gock"
 	"github.com/stretchr/testify/assert"
@@ -448,3 +449,201 @@ func TestSearch(t *testing.T) {
 		assert.EqualError(t, err, "search query is required")
 	})
 }
+
+func TestDefaults(t *testing.T) {
+	defaults := WithDefaults(Defaults{
+		Timezone: "America/New_York",
+		Adult:    true,
+		Start:    2002,
+		End:      2003,
+	})
+
+	t.Run("inherits defaults", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("adult", "true").
+			MatchParam("timezone", "America/New_York").
+			Reply(200).
+			File("testdata/getEvents-parameters.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "porch day").
+			MatchParam("adult", "true").
+			Reply(200).
+			File("testdata/search-parameters.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "f90b893ea04939d7456f30c54f68d7b4").
+			MatchParam("start", "2002").
+			MatchParam("end", "2003").
+			Reply(200).
+			File("testdata/getEventInfo-parameters.json")
+
+		api, _ := New("abc123", defaults)
+		_, err := api.GetEvents(GetEventsRequest{})
+		assert.Nil(t, err)
+		_, err = api.Search(SearchRequest{Query: "porch day"})
+		assert.Nil(t, err)
+		_, err = api.GetEventInfo(GetEventInfoRequest{Id: "f90b893ea04939d7456f30c54f68d7b4"})
+		assert.Nil(t, err)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("overrides defaults", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("adult", "false").
+			MatchParam("timezone", "America/Chicago").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "zucchini").
+			MatchParam("adult", "false").
+			Reply(200).
+			File("testdata/search-default.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "f90b893ea04939d7456f30c54f68d7b4").
+			MatchParam("start", "2020").
+			MatchParam("end", "2025").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		api, _ := New("abc123", defaults)
+		_, err := api.GetEvents(GetEventsRequest{ExcludeAdult: true, Timezone: "America/Chicago"})
+		assert.Nil(t, err)
+		_, err = api.Search(SearchRequest{Query: "zucchini", ExcludeAdult: true})
+		assert.Nil(t, err)
+		_, err = api.GetEventInfo(GetEventInfoRequest{Id: "f90b893ea04939d7456f30c54f68d7b4", Start: 2020, End: 2025})
+		assert.Nil(t, err)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("rejects conflicting adult parameters", func(t *testing.T) {
+		api, _ := New("abc123")
+
+		response, err := api.GetEvents(GetEventsRequest{Adult: true, ExcludeAdult: true})
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "adult and exclude adult can't both be set")
+
+		search, err := api.Search(SearchRequest{Query: "zucchini", Adult: true, ExcludeAdult: true})
+		assert.Nil(t, search)
+		assert.EqualError(t, err, "adult and exclude adult can't both be set")
+	})
+}
+
+func TestRawResponse(t *testing.T) {
+	t.Run("keeps the raw response", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			SetHeader("X-Request-Id", "req-1").
+			BodyString(`{"timezone": "America/Chicago"}`)
+
+		api, _ := New("abc123", WithRawResponse())
+		response, err := api.GetEvents(GetEventsRequest{Date: "today"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "America/Chicago", response.Timezone)
+		assert.Equal(t, 200, response.Raw.StatusCode)
+		assert.Equal(t, "req-1", response.Raw.Header.Get("X-Request-Id"))
+		assert.Equal(t, `{"timezone": "America/Chicago"}`, string(response.Raw.Body))
+		assert.Equal(t, "https://api.apilayer.com/checkiday/events?adult=false&date=today", response.Raw.Url)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("works with other decode modes", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			Reply(200).
+			BodyString(`{"query": "zucchini", "region": "US"}`)
+
+		api, _ := New("abc123", WithRawResponse(), WithDecodeMode(DecodeLenient))
+		response, err := api.Search(SearchRequest{Query: "zucchini"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "zucchini", response.Query)
+		assert.Contains(t, response.Extra, "region")
+		assert.Equal(t, `{"query": "zucchini", "region": "US"}`, string(response.Raw.Body))
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("is off by default", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+		assert.Nil(t, response.Raw)
+
+		assert.True(t, gock.IsDone())
+	})
+}
+
+func TestMetadata(t *testing.T) {
+	t.Run("reports request metadata", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			SetHeader("X-Amzn-RequestId", "req-1").
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "req-1", response.Metadata.RequestId)
+		assert.Greater(t, response.Metadata.Latency, time.Duration(0))
+		assert.Equal(t, "https://api.apilayer.com/checkiday/events?adult=false", response.Metadata.FinalUrl)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("reports the URL after redirects", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(302).
+			SetHeader("Location", "https://api.apilayer.com/checkiday/redirected?apikey=abc123")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/redirected").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "", response.Metadata.RequestId)
+		assert.Equal(t, "https://api.apilayer.com/checkiday/redirected?apikey=REDACTED", response.Metadata.FinalUrl)
+
+		assert.True(t, gock.IsDone())
+	})
+}
@@ -0,0 +1,188 @@
+package holidays
+
+import (
+	"html"
+	"strings"
+)
+
+// The kinds of HTML tokens
+type htmlTokenType int
+
+const (
+	htmlText htmlTokenType = iota
+	htmlStartTag
+	htmlEndTag
+)
+
+// A token of the small HTML subset the API returns
+type htmlToken struct {
+	Type        htmlTokenType
+	Data        string     // The unescaped text, or the lowercased tag name
+	Attrs       []htmlAttr // The tag's attributes, with unescaped values
+	SelfClosing bool       // Whether the start tag ended with "/>"
+}
+
+// An HTML attribute
+type htmlAttr struct {
+	Name  string // The lowercased attribute name
+	Value string // The unescaped attribute value
+}
+
+// Gets the value of the named attribute
+func (t htmlToken) attr(name string) (string, bool) {
+	for _, attr := range t.Attrs {
+		if attr.Name == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// Splits HTML into text and tag tokens. Comments, doctypes and processing instructions are dropped.
+// Malformed markup is treated as text rather than rejected.
+func tokenizeHtml(s string) []htmlToken {
+	var tokens []htmlToken
+	var text strings.Builder
+
+	flush := func() {
+		if text.Len() > 0 {
+			tokens = append(tokens, htmlToken{Type: htmlText, Data: html.UnescapeString(text.String())})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			next := strings.IndexByte(s[i:], '<')
+			if next < 0 {
+				next = len(s) - i
+			}
+			text.WriteString(s[i : i+next])
+			i += next
+			continue
+		}
+
+		rest := s[i:]
+		switch {
+		case strings.HasPrefix(rest, "<!--"):
+			flush()
+			end := strings.Index(rest[4:], "-->")
+			if end < 0 {
+				return tokens
+			}
+			i += 4 + end + 3
+		case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+			flush()
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				return tokens
+			}
+			i += end + 1
+		case len(rest) > 2 && rest[1] == '/' && isAsciiLetter(rest[2]):
+			flush()
+			end := strings.IndexByte(rest, '>')
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name, _ := readTagName(rest[2:end])
+			tokens = append(tokens, htmlToken{Type: htmlEndTag, Data: name})
+			i += end + 1
+		case len(rest) > 1 && isAsciiLetter(rest[1]):
+			flush()
+			token, n := readStartTag(rest)
+			tokens = append(tokens, token)
+			i += n
+		default:
+			text.WriteByte('<')
+			i++
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// Reads a start tag from the beginning of s, returning the token and its length
+func readStartTag(s string) (htmlToken, int) {
+	name, i := readTagName(s[1:])
+	i++
+	token := htmlToken{Type: htmlStartTag, Data: name}
+
+	for i < len(s) {
+		for i < len(s) && isHtmlSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		if s[i] == '>' {
+			return token, i + 1
+		}
+		if s[i] == '/' {
+			i++
+			if i < len(s) && s[i] == '>' {
+				token.SelfClosing = true
+				return token, i + 1
+			}
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isHtmlSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		attr := htmlAttr{Name: strings.ToLower(s[start:i])}
+
+		for i < len(s) && isHtmlSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isHtmlSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				attr.Value = s[i+1 : i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(s) && !isHtmlSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				attr.Value = s[start:i]
+			}
+			attr.Value = html.UnescapeString(attr.Value)
+		}
+		token.Attrs = append(token.Attrs, attr)
+	}
+
+	if i > len(s) {
+		i = len(s)
+	}
+	return token, i
+}
+
+// Reads a lowercased tag name from the beginning of s, returning it and its length
+func readTagName(s string) (string, int) {
+	i := 0
+	for i < len(s) && (isAsciiLetter(s[i]) || (s[i] >= '0' && s[i] <= '9')) {
+		i++
+	}
+	return strings.ToLower(s[:i]), i
+}
+
+func isAsciiLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isHtmlSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// Escapes text for use in HTML text or double-quoted attribute values
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
@@ -0,0 +1,176 @@
+package images
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"  // register the GIF decoder for dimensions
+	_ "image/jpeg" // register the JPEG decoder for dimensions
+	_ "image/png"  // register the PNG decoder for dimensions
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// The largest image Store accepts
+const maxImageBytes = 10 << 20
+
+// The error returned when a download isn't a supported image
+var ErrNotImage = errors.New("not an image")
+
+// Matches image hashes: a hex encoded SHA-256
+var hashPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Information about a cached image
+type Metadata struct {
+	Url         string    `json:"url"`          // The URL the image was downloaded from
+	Size        Size      `json:"size"`         // The size the image was downloaded as
+	Hash        string    `json:"hash"`         // The SHA-256 of the image content, hex encoded
+	ContentType string    `json:"content_type"` // The image's content type, e.g. "image/jpeg"
+	Width       int       `json:"width"`        // The image width in pixels
+	Height      int       `json:"height"`       // The image height in pixels
+	Bytes       int64     `json:"bytes"`        // The image file size
+	FetchedAt   time.Time `json:"fetched_at"`   // When the image was downloaded
+}
+
+// A content-addressed image cache on the local filesystem.
+// Image files are stored by the hash of their content, so identical images are stored once,
+// and a small JSON index maps each URL to its Metadata.
+type Cache struct {
+	dir string
+}
+
+// Opens the Cache in dir, creating the directory if needed
+func NewCache(dir string) (*Cache, error) {
+	for _, sub := range []string{"objects", "index"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("can't create cache: %w", err)
+		}
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Gets the Metadata of the image cached for url
+func (c *Cache) Lookup(url string) (Metadata, bool, error) {
+	b, err := os.ReadFile(c.indexPath(url))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Metadata{}, false, nil
+	}
+	if err != nil {
+		return Metadata{}, false, fmt.Errorf("can't read cache index: %w", err)
+	}
+
+	var meta Metadata
+	if err := json.Unmarshal(b, &meta); err != nil {
+		return Metadata{}, false, fmt.Errorf("can't parse cache index: %w", err)
+	}
+	if !hashPattern.MatchString(meta.Hash) {
+		// a damaged entry, which the next Store replaces
+		return Metadata{}, false, nil
+	}
+	if _, err := os.Stat(c.Path(meta)); err != nil {
+		// the image file was removed, so the entry is stale
+		return Metadata{}, false, nil
+	}
+
+	return meta, true, nil
+}
+
+// Gets the path of a cached image file, or "" if meta has no valid hash
+func (c *Cache) Path(meta Metadata) string {
+	if !hashPattern.MatchString(meta.Hash) {
+		return ""
+	}
+	return filepath.Join(c.dir, "objects", meta.Hash[:2], meta.Hash)
+}
+
+// Stores the image read from r as the image for url.
+// Images larger than 10 MiB, and content that isn't a GIF, JPEG or PNG image, are rejected.
+func (c *Cache) Store(url string, size Size, contentType string, r io.Reader) (Metadata, error) {
+	if contentType != "" && !isImageType(contentType) {
+		return Metadata{}, fmt.Errorf("%w: %s has content type %q", ErrNotImage, url, contentType)
+	}
+	content, err := io.ReadAll(io.LimitReader(r, maxImageBytes+1))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("can't read image: %w", err)
+	}
+	if len(content) > maxImageBytes {
+		return Metadata{}, fmt.Errorf("can't store %s: image is larger than %d bytes", url, maxImageBytes)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return Metadata{}, fmt.Errorf("%w: can't decode %s: %w", ErrNotImage, url, err)
+	}
+
+	sum := sha256.Sum256(content)
+	meta := Metadata{
+		Url:         url,
+		Size:        size,
+		Hash:        hex.EncodeToString(sum[:]),
+		ContentType: contentType,
+		Bytes:       int64(len(content)),
+		Width:       config.Width,
+		Height:      config.Height,
+		FetchedAt:   time.Now().UTC(),
+	}
+	if meta.ContentType == "" {
+		meta.ContentType = http.DetectContentType(content)
+	}
+
+	path := c.Path(meta)
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return Metadata{}, fmt.Errorf("can't write image: %w", err)
+		}
+		if err := writeFile(path, content); err != nil {
+			return Metadata{}, fmt.Errorf("can't write image: %w", err)
+		}
+	}
+
+	index, err := json.Marshal(meta)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("can't encode cache index: %w", err)
+	}
+	if err := writeFile(c.indexPath(url), index); err != nil {
+		return Metadata{}, fmt.Errorf("can't write cache index: %w", err)
+	}
+
+	return meta, nil
+}
+
+// Reports whether a content type is an image type
+func isImageType(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(contentType)), "image/")
+}
+
+func (c *Cache) indexPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, "index", hex.EncodeToString(sum[:])+".json")
+}
+
+// Writes a file through a temporary file, so concurrent readers never see a partial file
+func writeFile(path string, content []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
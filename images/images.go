@@ -0,0 +1,173 @@
+// Package images downloads Event images into a local cache for offline display.
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// An image size
+type Size int
+
+const (
+	Small  Size = iota // The small image, about 300 pixels wide
+	Medium             // The medium image, about 600 pixels wide
+	Large              // The large image, about 1200 pixels wide
+)
+
+// The default number of concurrent downloads
+const defaultConcurrency = 4
+
+// The error returned when an Event has no image in any size
+var ErrNoImage = errors.New("event has no image")
+
+func (s Size) String() string {
+	switch s {
+	case Small:
+		return "small"
+	case Medium:
+		return "medium"
+	case Large:
+		return "large"
+	}
+	return fmt.Sprintf("Size(%d)", int(s))
+}
+
+// Gets the URL of an image size
+func (s Size) url(image holidays.ImageInfo) string {
+	switch s {
+	case Small:
+		return image.Small
+	case Medium:
+		return image.Medium
+	case Large:
+		return image.Large
+	}
+	return ""
+}
+
+// Gets the sizes to try for a requested size: the size itself, then smaller sizes, then larger sizes
+func fallbacks(size Size) []Size {
+	sizes := []Size{size}
+	for s := size - 1; s >= Small; s-- {
+		sizes = append(sizes, s)
+	}
+	for s := size + 1; s <= Large; s++ {
+		sizes = append(sizes, s)
+	}
+	return sizes
+}
+
+// Downloads Event images into a Cache
+type Fetcher struct {
+	Cache       *Cache       // Where to store images. Required.
+	Client      *http.Client // The HTTP Client to use. Defaults to http.DefaultClient.
+	Concurrency int          // The maximum number of concurrent downloads. Defaults to 4.
+}
+
+// The outcome of fetching an Event's image
+type Result struct {
+	EventId  string   // The Event Id
+	Metadata Metadata // The image's Metadata, including the Size actually fetched
+	Path     string   // The path of the cached image file
+	Err      error    // Why the image couldn't be fetched, if it couldn't
+}
+
+// Fetches each Event's image in the requested size, falling back to other sizes when it is missing.
+// Cached images are not downloaded again. Results are in the same order as events.
+func (f *Fetcher) Fetch(ctx context.Context, events []holidays.EventInfo, size Size) []Result {
+	concurrency := f.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultConcurrency
+	}
+
+	results := make([]Result, len(events))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, event := range events {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+				results[i] = f.FetchOne(ctx, event, size)
+			case <-ctx.Done():
+				results[i] = Result{EventId: event.Id, Err: ctx.Err()}
+			}
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Fetches an Event's image in the requested size, falling back to other sizes when it is missing
+func (f *Fetcher) FetchOne(ctx context.Context, event holidays.EventInfo, size Size) Result {
+	result := Result{EventId: event.Id, Err: ErrNoImage}
+
+	for _, s := range fallbacks(size) {
+		url := s.url(event.Image)
+		if url == "" {
+			continue
+		}
+
+		meta, err := f.fetch(ctx, url, s)
+		if errors.Is(err, errNotFound) {
+			continue
+		}
+		if err != nil {
+			result.Err = err
+			return result
+		}
+
+		return Result{
+			EventId:  event.Id,
+			Metadata: meta,
+			Path:     f.Cache.Path(meta),
+		}
+	}
+
+	return result
+}
+
+var errNotFound = errors.New("image not found")
+
+// Gets the image at url from the Cache, downloading it if needed
+func (f *Fetcher) fetch(ctx context.Context, url string, size Size) (Metadata, error) {
+	if meta, ok, err := f.Cache.Lookup(url); err != nil || ok {
+		return meta, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("can't create request: %w", err)
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return Metadata{}, fmt.Errorf("can't download %s: %w", url, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusGone {
+		return Metadata{}, errNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		return Metadata{}, fmt.Errorf("can't download %s: %s", url, res.Status)
+	}
+
+	return f.Cache.Store(url, size, res.Header.Get("Content-Type"), res.Body)
+}
@@ -0,0 +1,221 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+func pngImage(width, height int) []byte {
+	var b bytes.Buffer
+	png.Encode(&b, image.NewRGBA(image.Rect(0, 0, width, height)))
+	return b.Bytes()
+}
+
+func newServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.Path {
+		case "/300/cat.png":
+			w.Write(pngImage(3, 2))
+		case "/600/cat.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngImage(6, 4))
+		case "/page.html":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>Not Found</html>"))
+		case "/broken.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("not really a PNG"))
+		case "/huge.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngImage(3, 2))
+			w.Write(make([]byte, maxImageBytes))
+		case "/error.png":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetcher(t *testing.T) {
+	t.Run("fetches and caches images", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newServer(t, &requests)
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache}
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Id: "cat"},
+			Image:        holidays.ImageInfo{Medium: server.URL + "/600/cat.png"},
+		}
+
+		result := fetcher.FetchOne(context.Background(), event, Medium)
+
+		assert.Nil(t, result.Err)
+		assert.Equal(t, "cat", result.EventId)
+		assert.Equal(t, Medium, result.Metadata.Size)
+		assert.Equal(t, "image/png", result.Metadata.ContentType)
+		assert.Equal(t, 6, result.Metadata.Width)
+		assert.Equal(t, 4, result.Metadata.Height)
+		content, _ := os.ReadFile(result.Path)
+		assert.Equal(t, pngImage(6, 4), content)
+
+		again := fetcher.FetchOne(context.Background(), event, Medium)
+		assert.Equal(t, result, again)
+		assert.Equal(t, int32(1), requests.Load())
+	})
+
+	t.Run("falls back to other sizes", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newServer(t, &requests)
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache}
+		event := holidays.EventInfo{
+			Image: holidays.ImageInfo{
+				Small: server.URL + "/300/cat.png",
+				Large: server.URL + "/1200/cat.png",
+			},
+		}
+
+		result := fetcher.FetchOne(context.Background(), event, Large)
+
+		assert.Nil(t, result.Err)
+		assert.Equal(t, Small, result.Metadata.Size)
+		assert.Equal(t, server.URL+"/300/cat.png", result.Metadata.Url)
+		assert.Equal(t, "image/png", result.Metadata.ContentType)
+		assert.Equal(t, 3, result.Metadata.Width)
+		assert.Equal(t, int32(2), requests.Load())
+	})
+
+	t.Run("reports missing and failed images", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newServer(t, &requests)
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache}
+
+		missing := fetcher.FetchOne(context.Background(), holidays.EventInfo{}, Medium)
+		assert.ErrorIs(t, missing.Err, ErrNoImage)
+
+		failed := fetcher.FetchOne(context.Background(), holidays.EventInfo{
+			Image: holidays.ImageInfo{Medium: server.URL + "/error.png"},
+		}, Medium)
+		assert.EqualError(t, failed.Err, "can't download "+server.URL+"/error.png: 500 Internal Server Error")
+	})
+
+	t.Run("rejects content that isn't an image", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newServer(t, &requests)
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache}
+
+		for _, path := range []string{"/page.html", "/broken.png"} {
+			result := fetcher.FetchOne(context.Background(), holidays.EventInfo{
+				Image: holidays.ImageInfo{Small: server.URL + path},
+			}, Small)
+			assert.ErrorIs(t, result.Err, ErrNotImage)
+
+			_, ok, err := cache.Lookup(server.URL + path)
+			assert.False(t, ok)
+			assert.Nil(t, err)
+		}
+
+		huge := fetcher.FetchOne(context.Background(), holidays.EventInfo{
+			Image: holidays.ImageInfo{Small: server.URL + "/huge.png"},
+		}, Small)
+		assert.ErrorContains(t, huge.Err, "image is larger than 10485760 bytes")
+	})
+
+	t.Run("stores identical images once", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newServer(t, &requests)
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache}
+
+		results := fetcher.Fetch(context.Background(), []holidays.EventInfo{
+			{EventSummary: holidays.EventSummary{Id: "a"}, Image: holidays.ImageInfo{Small: server.URL + "/300/cat.png"}},
+			{EventSummary: holidays.EventSummary{Id: "b"}, Image: holidays.ImageInfo{Small: server.URL + "/300/cat.png?v=2"}},
+		}, Small)
+
+		assert.Equal(t, "a", results[0].EventId)
+		assert.Equal(t, "b", results[1].EventId)
+		assert.Equal(t, results[0].Path, results[1].Path)
+	})
+
+	t.Run("bounds concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				max := maxInFlight.Load()
+				if n <= max || maxInFlight.CompareAndSwap(max, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			w.Write(pngImage(len(r.URL.Path), 1))
+		}))
+		defer server.Close()
+		cache, _ := NewCache(t.TempDir())
+		fetcher := &Fetcher{Cache: cache, Concurrency: 2}
+
+		var events []holidays.EventInfo
+		for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+			events = append(events, holidays.EventInfo{Image: holidays.ImageInfo{Small: server.URL + "/" + name}})
+		}
+		results := fetcher.Fetch(context.Background(), events, Small)
+
+		for _, result := range results {
+			assert.Nil(t, result.Err)
+		}
+		assert.Equal(t, int32(2), maxInFlight.Load())
+	})
+}
+
+func TestFallbacks(t *testing.T) {
+	assert.Equal(t, []Size{Large, Medium, Small}, fallbacks(Large))
+	assert.Equal(t, []Size{Medium, Small, Large}, fallbacks(Medium))
+	assert.Equal(t, []Size{Small, Medium, Large}, fallbacks(Small))
+}
+
+func TestCache(t *testing.T) {
+	t.Run("treats damaged index entries as misses", func(t *testing.T) {
+		cache, _ := NewCache(t.TempDir())
+		for _, index := range []string{`{}`, `{"hash":"ab"}`, `{"hash":"../../../../etc/passwd"}`} {
+			os.WriteFile(cache.indexPath("https://example.com/cat.png"), []byte(index), 0o644)
+
+			meta, ok, err := cache.Lookup("https://example.com/cat.png")
+			assert.Equal(t, Metadata{}, meta)
+			assert.False(t, ok)
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, "", cache.Path(Metadata{}))
+	})
+
+	t.Run("replaces damaged index entries", func(t *testing.T) {
+		cache, _ := NewCache(t.TempDir())
+		os.WriteFile(cache.indexPath("https://example.com/cat.png"), []byte(`{}`), 0o644)
+
+		stored, err := cache.Store("https://example.com/cat.png", Small, "", bytes.NewReader(pngImage(3, 2)))
+		assert.Nil(t, err)
+		assert.Equal(t, "image/png", stored.ContentType)
+
+		meta, ok, err := cache.Lookup("https://example.com/cat.png")
+		assert.True(t, ok)
+		assert.Nil(t, err)
+		assert.Equal(t, stored, meta)
+	})
+}
@@ -0,0 +1,69 @@
+// Package testutil has the golden file and fixture helpers shared by the module's tests.
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// Compares got to the golden file testdata/name, rewriting it when -update is set
+func AssertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(want), string(got))
+}
+
+// Compares v, encoded as indented JSON, to the golden file testdata/name
+func AssertGoldenJSON(t *testing.T, name string, v any) {
+	t.Helper()
+
+	var got bytes.Buffer
+	encoder := json.NewEncoder(&got)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		t.Fatal(err)
+	}
+	AssertGolden(t, name, got.Bytes())
+}
+
+// Loads an API response fixture from the root testdata directory
+func LoadFixture[R any](t *testing.T, name string) *R {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join(rootDir(), "testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var res R
+	if err := json.Unmarshal(b, &res); err != nil {
+		t.Fatal(err)
+	}
+	return &res
+}
+
+// Gets the module's root directory, two levels above this file
+func rootDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..")
+}
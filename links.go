@@ -0,0 +1,147 @@
+package holidays
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/westy92/holiday-event-api-go/checkidayurl"
+)
+
+// The kinds of Links found in rich text
+type LinkKind int
+
+const (
+	ExternalLink       LinkKind = iota // A link to another site
+	CheckidayEventLink                 // A link to an Event page on checkiday.com, e.g. /f90b893ea04939d7456f30c54f68d7b4/international-cat-day
+	CheckidayDateLink                  // A link to a date page on checkiday.com, e.g. /8/8
+	CheckidayLink                      // Any other link to checkiday.com
+)
+
+// A link found in rich text
+type Link struct {
+	Text    string   // The link text
+	Href    string   // The link URL
+	Domain  string   // The link's host, lowercased and without "www."
+	Kind    LinkKind // What the link points to
+	EventId string   // The linked Event Id, for CheckidayEventLinks
+	Date    *DateRef // The linked date, for CheckidayDateLinks
+}
+
+// A month and day, as linked to by checkiday.com date pages
+type DateRef struct {
+	Month time.Month // The month
+	Day   int        // The day of the month
+}
+
+// Gets the DateRef's date in the given year, at midnight in loc.
+// Fails if the year doesn't have the date, like February 29th outside leap years.
+func (d DateRef) In(year int, loc *time.Location) (time.Time, error) {
+	date := time.Date(year, d.Month, d.Day, 0, 0, 0, 0, loc)
+	if date.Month() != d.Month || date.Day() != d.Day {
+		return time.Time{}, fmt.Errorf("%s %d doesn't exist in %d", d.Month, d.Day, year)
+	}
+	return date, nil
+}
+
+// Gets a GetEventsRequest for the DateRef's date in the given year, failing like In
+func (d DateRef) GetEventsRequest(year int) (GetEventsRequest, error) {
+	date, err := d.In(year, time.UTC)
+	if err != nil {
+		return GetEventsRequest{}, err
+	}
+	return GetEventsRequest{
+		Date: date.Format(DateLayout),
+	}, nil
+}
+
+// Gets the links in the HTML text, in order
+func (r RichText) Links() []Link {
+	return parseLinks(r.Html)
+}
+
+// Gets the links in ObservedHtml, in order
+func (p Pattern) Links() []Link {
+	return parseLinks(p.ObservedHtml)
+}
+
+// Gets the unique links in the Event's description, how to observe and patterns, in order
+func (e EventInfo) Links() []Link {
+	var links []Link
+	seen := map[string]bool{}
+	add := func(found []Link) {
+		for _, link := range found {
+			if !seen[link.Href] {
+				seen[link.Href] = true
+				links = append(links, link)
+			}
+		}
+	}
+
+	add(e.Description.Links())
+	add(e.HowToObserve.Links())
+	for _, pattern := range e.Patterns {
+		add(pattern.Links())
+	}
+
+	return links
+}
+
+// Gets the anchors in HTML
+func parseLinks(s string) []Link {
+	var links []Link
+	var current *Link
+	var text strings.Builder
+
+	for _, token := range tokenizeHtml(s) {
+		switch {
+		case token.Type == htmlStartTag && token.Data == "a":
+			href, ok := token.attr("href")
+			if !ok || current != nil {
+				continue
+			}
+			link := classifyLink(strings.TrimSpace(href))
+			current = &link
+			text.Reset()
+		case token.Type == htmlEndTag && token.Data == "a" && current != nil:
+			current.Text = strings.Join(strings.Fields(text.String()), " ")
+			links = append(links, *current)
+			current = nil
+		case token.Type == htmlText && current != nil:
+			text.WriteString(token.Data)
+		}
+	}
+
+	return links
+}
+
+// Creates a Link for href, classifying checkiday.com links
+func classifyLink(href string) Link {
+	link := Link{Href: href, Kind: ExternalLink}
+
+	u, err := url.Parse(href)
+	if err != nil {
+		return link
+	}
+	link.Domain = strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if link.Domain != "checkiday.com" {
+		return link
+	}
+
+	link.Kind = CheckidayLink
+	page, err := checkidayurl.Parse(href)
+	if err != nil {
+		return link
+	}
+	switch {
+	case page.Kind == checkidayurl.EventPage && page.Event.Id != "":
+		link.Kind = CheckidayEventLink
+		link.EventId = page.Event.Id
+	case page.Kind == checkidayurl.DatePage:
+		link.Kind = CheckidayDateLink
+		link.Date = &DateRef{Month: page.Date.Month, Day: page.Date.Day}
+	}
+
+	return link
+}
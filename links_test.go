@@ -0,0 +1,79 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinks(t *testing.T) {
+	t.Run("finds pattern date links", func(t *testing.T) {
+		pattern := loadEventInfo(t).Patterns[0]
+
+		assert.Equal(t, []Link{{
+			Text:   "August 8th",
+			Href:   "https://www.checkiday.com/8/8",
+			Domain: "checkiday.com",
+			Kind:   CheckidayDateLink,
+			Date:   &DateRef{Month: time.August, Day: 8},
+		}}, pattern.Links())
+	})
+
+	t.Run("finds external links", func(t *testing.T) {
+		links := loadEventInfo(t).HowToObserve.Links()
+
+		assert.Len(t, links, 3)
+		assert.Equal(t, "collars", links[0].Text)
+		assert.Equal(t, "https://www.amazon.com/s?url=search-alias=aps&field-keywords=birdbesafe+cat+collar&sprefix=birdbesafe,aps,169&crid=3685VO6WFTRUL&tag=checkiday08-20", links[0].Href)
+		assert.Equal(t, "amazon.com", links[0].Domain)
+		assert.Equal(t, ExternalLink, links[0].Kind)
+		assert.Equal(t, "secure.ifaw.org", links[2].Domain)
+	})
+
+	t.Run("collects an event's unique links", func(t *testing.T) {
+		links := loadEventInfo(t).Links()
+
+		assert.Len(t, links, 4)
+		assert.Equal(t, "August 8th", links[3].Text)
+	})
+
+	t.Run("classifies checkiday links", func(t *testing.T) {
+		links := RichText{Html: `<a href="https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day">Cat <em>Day</em></a>
+			<a href="https://checkiday.com/2/29">Leap Day</a>
+			<a href="https://www.checkiday.com/2/30">Not a day</a>
+			<a href="https://www.checkiday.com/about">About</a>
+			<a name="anchor">No href</a>`}.Links()
+
+		assert.Equal(t, []Link{
+			{Text: "Cat Day", Href: "https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day", Domain: "checkiday.com", Kind: CheckidayEventLink, EventId: "f90b893ea04939d7456f30c54f68d7b4"},
+			{Text: "Leap Day", Href: "https://checkiday.com/2/29", Domain: "checkiday.com", Kind: CheckidayDateLink, Date: &DateRef{Month: time.February, Day: 29}},
+			{Text: "Not a day", Href: "https://www.checkiday.com/2/30", Domain: "checkiday.com", Kind: CheckidayLink},
+			{Text: "About", Href: "https://www.checkiday.com/about", Domain: "checkiday.com", Kind: CheckidayLink},
+		}, links)
+	})
+
+	t.Run("maps date links to GetEvents requests", func(t *testing.T) {
+		date := DateRef{Month: time.August, Day: 8}
+
+		req, err := date.GetEventsRequest(2025)
+		assert.Nil(t, err)
+		assert.Equal(t, GetEventsRequest{Date: "08/08/2025"}, req)
+	})
+
+	t.Run("maps leap days only in leap years", func(t *testing.T) {
+		date := DateRef{Month: time.February, Day: 29}
+
+		req, err := date.GetEventsRequest(2024)
+		assert.Nil(t, err)
+		assert.Equal(t, GetEventsRequest{Date: "02/29/2024"}, req)
+
+		req, err = date.GetEventsRequest(2025)
+		assert.Equal(t, GetEventsRequest{}, req)
+		assert.EqualError(t, err, "February 29 doesn't exist in 2025")
+
+		at, err := date.In(2025, time.UTC)
+		assert.True(t, at.IsZero())
+		assert.EqualError(t, err, "February 29 doesn't exist in 2025")
+	})
+}
@@ -0,0 +1,178 @@
+package holidays
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/westy92/holiday-event-api-go/checkidayurl"
+)
+
+// How confident a lookup must be to pick its best candidate
+const (
+	lookupMinScore  = 0.75 // the lowest score a picked candidate can have
+	lookupMinMargin = 0.15 // how much better than the runner-up a picked candidate must be
+)
+
+// The error returned when no Event matches a lookup
+var ErrEventNotFound = errors.New("no matching event found")
+
+var nonAlnumRuns = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+
+// The Request struct for calling GetEventInfoByName
+type GetEventInfoByNameRequest struct {
+	Name         string // The Event name, e.g. "International Cat Day". Must be at least 3 characters long.
+	Adult        bool   // Include events that may be unsafe for viewing at work or by children. Defaults to the Client's default, or false.
+	ExcludeAdult bool   // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
+	Start        int    // The starting range of returned occurrences. Optional, defaults to the Client's default, or 2 years prior.
+	End          int    // The ending range of returned occurrences. Optional, defaults to the Client's default, or 3 years in the future.
+}
+
+// The Request struct for calling GetEventInfoByUrl
+type GetEventInfoByUrlRequest struct {
+	Url          string // An Event URL or its path, e.g. https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day or /international-cat-day
+	Adult        bool   // Include events that may be unsafe for viewing at work or by children, when the URL has no Event Id. Defaults to the Client's default, or false.
+	ExcludeAdult bool   // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
+	Start        int    // The starting range of returned occurrences. Optional, defaults to the Client's default, or 2 years prior.
+	End          int    // The ending range of returned occurrences. Optional, defaults to the Client's default, or 3 years in the future.
+}
+
+// The Response struct returned by GetEventInfoByName and GetEventInfoByUrl
+type EventLookupResponse struct {
+	GetEventInfoResponse         // The Event Info of the picked Event
+	Confidence           float64 // How well the picked Event matches, from 0 to 1. 1 is an exact match.
+}
+
+// An Event that may be the one a lookup is for
+type EventCandidate struct {
+	EventSummary
+	Score float64 // How well the Event matches, from 0 to 1. 1 is an exact match.
+}
+
+// The error returned when a lookup can't confidently pick an Event
+type AmbiguousEventError struct {
+	Query      string           // What was looked up
+	Candidates []EventCandidate // The possible Events, best first
+}
+
+func (e *AmbiguousEventError) Error() string {
+	names := make([]string, 0, len(e.Candidates))
+	for i, candidate := range e.Candidates {
+		if i == 5 {
+			names = append(names, fmt.Sprintf("and %d more", len(e.Candidates)-i))
+			break
+		}
+		names = append(names, fmt.Sprintf("%s (%.2f)", candidate.Name, candidate.Score))
+	}
+	return fmt.Sprintf("%q is ambiguous, candidates: %s", e.Query, strings.Join(names, ", "))
+}
+
+// Gets the Event Info for the Event with the given name.
+// The name is searched for and the best match is picked, failing with an *AmbiguousEventError if no match is clearly best.
+func (c *Client) GetEventInfoByName(req GetEventInfoByNameRequest) (*EventLookupResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, errors.New("event name is required")
+	}
+
+	return c.lookup(req.Name, req.Adult, req.ExcludeAdult, req.Start, req.End, func(event EventSummary) float64 {
+		return nameScore(req.Name, event.Name)
+	})
+}
+
+// Gets the Event Info for the Event at the given URL.
+// URLs with an Event Id are fetched directly. URLs with only a slug are searched for, and must match an Event's slug.
+func (c *Client) GetEventInfoByUrl(req GetEventInfoByUrlRequest) (*EventLookupResponse, error) {
+	page, err := checkidayurl.ParseEvent(req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Id != "" {
+		res, err := c.GetEventInfo(GetEventInfoRequest{Id: page.Id, Start: req.Start, End: req.End})
+		if err != nil {
+			return nil, err
+		}
+		return &EventLookupResponse{GetEventInfoResponse: *res, Confidence: 1}, nil
+	}
+
+	return c.lookup(strings.ReplaceAll(page.Slug, "-", " "), req.Adult, req.ExcludeAdult, req.Start, req.End, func(event EventSummary) float64 {
+		if eventPage, err := checkidayurl.ParseEvent(event.Url); err == nil && eventPage.Slug == page.Slug {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Searches for query, scores the found Events and gets the Event Info of the best one
+func (c *Client) lookup(query string, adult, excludeAdult bool, start, end int, score func(EventSummary) float64) (*EventLookupResponse, error) {
+	search, err := c.Search(SearchRequest{Query: query, Adult: adult, ExcludeAdult: excludeAdult})
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []EventCandidate
+	for _, event := range search.Events {
+		if s := score(event); s > 0 {
+			candidates = append(candidates, EventCandidate{EventSummary: event, Score: s})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Score > candidates[j].Score
+	})
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w for %q", ErrEventNotFound, query)
+	}
+	// a single exact match always wins, otherwise the best match must be good and clearly better than the rest
+	best := candidates[0]
+	runnerUp := 0.0
+	if len(candidates) > 1 {
+		runnerUp = candidates[1].Score
+	}
+	if best.Score < 1 || runnerUp == 1 {
+		if best.Score < lookupMinScore || best.Score-runnerUp < lookupMinMargin {
+			return nil, &AmbiguousEventError{Query: query, Candidates: candidates}
+		}
+	}
+
+	res, err := c.GetEventInfo(GetEventInfoRequest{Id: best.Id, Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+	return &EventLookupResponse{GetEventInfoResponse: *res, Confidence: best.Score}, nil
+}
+
+// Scores how well an Event name matches a looked up name, from 0 to 1
+func nameScore(query, name string) float64 {
+	q, n := normalizeName(query), normalizeName(name)
+	if q == "" || n == "" {
+		return 0
+	}
+	if q == n {
+		return 1
+	}
+
+	// the Dice coefficient of the words
+	queryWords := strings.Fields(q)
+	nameWords := map[string]int{}
+	for _, word := range strings.Fields(n) {
+		nameWords[word]++
+	}
+	shared := 0
+	for _, word := range queryWords {
+		if nameWords[word] > 0 {
+			nameWords[word]--
+			shared++
+		}
+	}
+	return 2 * float64(shared) / float64(len(queryWords)+len(strings.Fields(n)))
+}
+
+// Lowercases a name and reduces it to words, ignoring punctuation
+func normalizeName(name string) string {
+	name = strings.ToLower(name)
+	name = strings.NewReplacer("&", " and ", "'", "", "’", "").Replace(name)
+	return strings.TrimSpace(nonAlnumRuns.ReplaceAllString(name, " "))
+}
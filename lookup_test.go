@@ -0,0 +1,212 @@
+package holidays
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventInfoByName(t *testing.T) {
+	t.Run("picks exact matches", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "national zucchini day").
+			Reply(200).
+			File("testdata/search-default.json")
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "778e08321fc0ca4ec38fbf507c0e6c26").
+			MatchParam("start", "2020").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByName(GetEventInfoByNameRequest{
+			Name:  "national zucchini day",
+			Start: 2020,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1.0, response.Confidence)
+		assert.Equal(t, "International Cat Day", response.Event.Name)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("picks clearly best matches", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "Zucchini Bread Day").
+			Reply(200).
+			File("testdata/search-default.json")
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "cc81cbd8730098456f85f69798cbc867").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByName(GetEventInfoByNameRequest{
+			Name: "Zucchini Bread Day",
+		})
+
+		assert.Nil(t, err)
+		assert.InDelta(t, 0.857, response.Confidence, 0.001)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("fails on ambiguous names", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "zucchini").
+			Reply(200).
+			File("testdata/search-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByName(GetEventInfoByNameRequest{
+			Name: "zucchini",
+		})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, `"zucchini" is ambiguous, candidates: National Zucchini Day (0.50), National Zucchini Bread Day (0.40), Sneak Some Zucchini Onto Your Neighbor's Porch Day (0.22)`)
+		var ambiguous *AmbiguousEventError
+		assert.True(t, errors.As(err, &ambiguous))
+		assert.Len(t, ambiguous.Candidates, 3)
+		assert.Equal(t, "778e08321fc0ca4ec38fbf507c0e6c26", ambiguous.Candidates[0].Id)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("fails without matches", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "pie day").
+			Reply(200).
+			JSON(map[string]any{"query": "pie day", "events": []any{}})
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByName(GetEventInfoByNameRequest{
+			Name: "pie day",
+		})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, `no matching event found for "pie day"`)
+		assert.True(t, errors.Is(err, ErrEventNotFound))
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("missing name", func(t *testing.T) {
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByName(GetEventInfoByNameRequest{Name: " "})
+
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "event name is required")
+	})
+}
+
+func TestGetEventInfoByUrl(t *testing.T) {
+	t.Run("fetches urls with ids", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "f90b893ea04939d7456f30c54f68d7b4").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByUrl(GetEventInfoByUrlRequest{
+			Url: "https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1.0, response.Confidence)
+		assert.Equal(t, "International Cat Day", response.Event.Name)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("searches for slugs", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "national zucchini day").
+			Reply(200).
+			File("testdata/search-default.json")
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			MatchParam("id", "778e08321fc0ca4ec38fbf507c0e6c26").
+			Reply(200).
+			File("testdata/getEventInfo.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByUrl(GetEventInfoByUrlRequest{
+			Url: "/national-zucchini-day",
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1.0, response.Confidence)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("fails on unmatched slugs", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			MatchParam("query", "zucchini").
+			Reply(200).
+			File("testdata/search-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventInfoByUrl(GetEventInfoByUrlRequest{
+			Url: "checkiday.com/zucchini",
+		})
+
+		assert.Nil(t, response)
+		assert.True(t, errors.Is(err, ErrEventNotFound))
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("fails on other urls", func(t *testing.T) {
+		api, _ := New("abc123")
+
+		for raw, want := range map[string]string{
+			"https://example.com/international-cat-day": `not a checkiday.com URL: "https://example.com/international-cat-day"`,
+			"https://www.checkiday.com/8/8":             `"https://www.checkiday.com/8/8" is not an event URL`,
+			"/International Cat Day":                    `"/International Cat Day" is not an event URL`,
+			"international-cat-day":                     `not a checkiday.com URL: "international-cat-day"`,
+			"":                                          `not a checkiday.com URL: ""`,
+		} {
+			response, err := api.GetEventInfoByUrl(GetEventInfoByUrlRequest{Url: raw})
+
+			assert.Nil(t, response)
+			assert.EqualError(t, err, want)
+		}
+	})
+}
+
+func TestNameScore(t *testing.T) {
+	t.Run("scores names", func(t *testing.T) {
+		assert.Equal(t, 1.0, nameScore("Sneak Some Zucchini onto your neighbors porch day", "Sneak Some Zucchini Onto Your Neighbor's Porch Day"))
+		assert.Equal(t, 1.0, nameScore("Fish and Chips Day", "Fish & Chips Day"))
+		assert.Equal(t, 0.8, nameScore("Cat Day", "International Cat Day"))
+		assert.Equal(t, 0.0, nameScore("Dog", "International Cat Day"))
+		assert.Equal(t, 0.0, nameScore("!!", "International Cat Day"))
+	})
+}
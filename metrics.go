@@ -0,0 +1,228 @@
+package holidays
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The upper bounds (in seconds) of the request latency histogram buckets
+var latencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Client health and quota metrics. Safe for concurrent use.
+// Metrics implements expvar.Var (publish it with expvar.Publish) and http.Handler (serves the Prometheus text format).
+type Metrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	latencies map[string]*latencyHistogram
+	rateLimit *RateLimit
+}
+
+type requestKey struct {
+	endpoint string
+	status   string
+}
+
+type latencyHistogram struct {
+	counts []uint64 // Per-bucket counts, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// A point-in-time copy of a Client's Metrics
+type MetricsSnapshot struct {
+	Requests  []RequestCount              `json:"requests"`   // Request counts per endpoint and status
+	Latencies map[string]LatencyHistogram `json:"latencies"`  // Request latency histograms per endpoint
+	RateLimit *RateLimit                  `json:"rate_limit"` // The most recently reported rate limit, nil if none has been seen yet
+}
+
+// The number of requests made to an endpoint that resulted in a status
+type RequestCount struct {
+	Endpoint string `json:"endpoint"` // The API endpoint, e.g. "events"
+	Status   string `json:"status"`   // The HTTP status code, or "error" if no response was received
+	Count    uint64 `json:"count"`    // The number of requests
+}
+
+// A histogram of request latencies
+type LatencyHistogram struct {
+	Buckets []float64 `json:"buckets"` // The upper bounds of each bucket, in seconds
+	Counts  []uint64  `json:"counts"`  // The cumulative number of observations less than or equal to each bucket's upper bound
+	Count   uint64    `json:"count"`   // The total number of observations
+	Sum     float64   `json:"sum"`     // The sum of all observations, in seconds
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		requests:  map[requestKey]uint64{},
+		latencies: map[string]*latencyHistogram{},
+	}
+}
+
+// Records a completed request. A status of 0 means no response was received.
+func (m *Metrics) observe(endpoint string, status int, latency time.Duration) {
+	if m == nil {
+		return
+	}
+
+	key := requestKey{endpoint: endpoint, status: "error"}
+	if status != 0 {
+		key.status = strconv.Itoa(status)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[key]++
+
+	h, ok := m.latencies[endpoint]
+	if !ok {
+		h = &latencyHistogram{counts: make([]uint64, len(latencyBuckets))}
+		m.latencies[endpoint] = h
+	}
+	seconds := latency.Seconds()
+	for i, bound := range latencyBuckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// Records the most recently reported rate limit
+func (m *Metrics) observeRateLimit(rateLimit RateLimit) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rateLimit = &rateLimit
+}
+
+// Returns a point-in-time copy of the Metrics
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	snapshot := MetricsSnapshot{
+		Requests:  []RequestCount{},
+		Latencies: map[string]LatencyHistogram{},
+	}
+	if m == nil {
+		return snapshot
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, count := range m.requests {
+		snapshot.Requests = append(snapshot.Requests, RequestCount{
+			Endpoint: key.endpoint,
+			Status:   key.status,
+			Count:    count,
+		})
+	}
+	sort.Slice(snapshot.Requests, func(i, j int) bool {
+		a, b := snapshot.Requests[i], snapshot.Requests[j]
+		if a.Endpoint != b.Endpoint {
+			return a.Endpoint < b.Endpoint
+		}
+		return a.Status < b.Status
+	})
+
+	for endpoint, h := range m.latencies {
+		histogram := LatencyHistogram{
+			Buckets: append([]float64(nil), latencyBuckets...),
+			Counts:  make([]uint64, len(latencyBuckets)),
+			Count:   h.count,
+			Sum:     h.sum,
+		}
+		var cumulative uint64
+		for i, count := range h.counts {
+			cumulative += count
+			histogram.Counts[i] = cumulative
+		}
+		snapshot.Latencies[endpoint] = histogram
+	}
+
+	if m.rateLimit != nil {
+		rateLimit := *m.rateLimit
+		snapshot.RateLimit = &rateLimit
+	}
+
+	return snapshot
+}
+
+// Returns the Metrics as JSON, implementing expvar.Var
+func (m *Metrics) String() string {
+	b, err := json.Marshal(m.Snapshot())
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// Writes the Metrics in the Prometheus text exposition format
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	snapshot := m.Snapshot()
+	ew := &errWriter{w: w}
+
+	ew.printf("# HELP holidays_requests_total The number of API requests by endpoint and status.\n")
+	ew.printf("# TYPE holidays_requests_total counter\n")
+	for _, r := range snapshot.Requests {
+		ew.printf("holidays_requests_total{endpoint=%q,status=%q} %d\n", r.Endpoint, r.Status, r.Count)
+	}
+
+	endpoints := make([]string, 0, len(snapshot.Latencies))
+	for endpoint := range snapshot.Latencies {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	ew.printf("# HELP holidays_request_duration_seconds The API request latency by endpoint.\n")
+	ew.printf("# TYPE holidays_request_duration_seconds histogram\n")
+	for _, endpoint := range endpoints {
+		h := snapshot.Latencies[endpoint]
+		for i, bound := range h.Buckets {
+			ew.printf("holidays_request_duration_seconds_bucket{endpoint=%q,le=%q} %d\n", endpoint, strconv.FormatFloat(bound, 'g', -1, 64), h.Counts[i])
+		}
+		ew.printf("holidays_request_duration_seconds_bucket{endpoint=%q,le=\"+Inf\"} %d\n", endpoint, h.Count)
+		ew.printf("holidays_request_duration_seconds_sum{endpoint=%q} %s\n", endpoint, strconv.FormatFloat(h.Sum, 'g', -1, 64))
+		ew.printf("holidays_request_duration_seconds_count{endpoint=%q} %d\n", endpoint, h.Count)
+	}
+
+	if snapshot.RateLimit != nil {
+		ew.printf("# HELP holidays_ratelimit_limit_month The amount of requests allowed this month.\n")
+		ew.printf("# TYPE holidays_ratelimit_limit_month gauge\n")
+		ew.printf("holidays_ratelimit_limit_month %d\n", snapshot.RateLimit.LimitMonth)
+		ew.printf("# HELP holidays_ratelimit_remaining_month The amount of requests remaining this month.\n")
+		ew.printf("# TYPE holidays_ratelimit_remaining_month gauge\n")
+		ew.printf("holidays_ratelimit_remaining_month %d\n", snapshot.RateLimit.RemainingMonth)
+	}
+
+	return ew.err
+}
+
+// Serves the Metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m.WritePrometheus(w)
+}
+
+// Remembers the first write error so callers can check once at the end
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (ew *errWriter) printf(format string, args ...any) {
+	if ew.err != nil {
+		return
+	}
+	_, ew.err = fmt.Fprintf(ew.w, format, args...)
+}
@@ -0,0 +1,107 @@
+package holidays
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Run("counts requests per endpoint and status", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Times(2).
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/search").
+			Reply(400).
+			JSON(map[string]string{"error": "Please enter a longer search term."})
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/event").
+			ReplyError(errors.New("err"))
+
+		api, _ := New("abc123")
+		api.GetEvents(GetEventsRequest{})
+		api.GetEvents(GetEventsRequest{})
+		api.Search(SearchRequest{Query: "a"})
+		api.GetEventInfo(GetEventInfoRequest{Id: "hi"})
+
+		snapshot := api.Metrics().Snapshot()
+		assert.Equal(t, []RequestCount{
+			{Endpoint: "event", Status: "error", Count: 1},
+			{Endpoint: "events", Status: "200", Count: 2},
+			{Endpoint: "search", Status: "400", Count: 1},
+		}, snapshot.Requests)
+		assert.Equal(t, uint64(2), snapshot.Latencies["events"].Count)
+		assert.Equal(t, uint64(2), snapshot.Latencies["events"].Counts[len(latencyBuckets)-1])
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("reports the latest rate limit", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			SetHeader("X-RateLimit-Limit-Month", "100").
+			SetHeader("x-ratelimit-remaining-month", "88").
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		assert.Nil(t, api.Metrics().Snapshot().RateLimit)
+
+		api.GetEvents(GetEventsRequest{})
+
+		assert.Equal(t, &RateLimit{LimitMonth: 100, RemainingMonth: 88}, api.Metrics().Snapshot().RateLimit)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("writes the Prometheus text format", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			SetHeader("X-RateLimit-Limit-Month", "100").
+			SetHeader("x-ratelimit-remaining-month", "88").
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		api.GetEvents(GetEventsRequest{})
+
+		rec := httptest.NewRecorder()
+		api.Metrics().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+		body := rec.Body.String()
+
+		assert.True(t, strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain"))
+		assert.Contains(t, body, "holidays_requests_total{endpoint=\"events\",status=\"200\"} 1\n")
+		assert.Contains(t, body, "holidays_request_duration_seconds_bucket{endpoint=\"events\",le=\"+Inf\"} 1\n")
+		assert.Contains(t, body, "holidays_request_duration_seconds_count{endpoint=\"events\"} 1\n")
+		assert.Contains(t, body, "holidays_ratelimit_limit_month 100\n")
+		assert.Contains(t, body, "holidays_ratelimit_remaining_month 88\n")
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("is an expvar.Var", func(t *testing.T) {
+		api, _ := New("abc123")
+
+		var snapshot MetricsSnapshot
+		err := json.Unmarshal([]byte(api.Metrics().String()), &snapshot)
+
+		assert.Nil(t, err)
+		assert.Empty(t, snapshot.Requests)
+	})
+}
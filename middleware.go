@@ -0,0 +1,145 @@
+package holidays
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strings"
+)
+
+// Sends HTTP requests and returns HTTP responses, like *http.Client
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Adapts an ordinary function to a Doer
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Calls f(req)
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Wraps a Doer with additional behavior, such as adding headers, signing or logging
+type Middleware func(next Doer) Doer
+
+// The headers and query parameters that are redacted when dumped
+var redactedNames = []string{"apikey", "Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+const redacted = "REDACTED"
+
+// Adds Middleware to the Client's outgoing requests.
+// Middleware run in the order they are added: the first one added sees the outgoing request first and the incoming response last.
+// Requests already carry the API key and standard headers when they reach the first Middleware.
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// Wraps base with middleware so that middleware[0] is the outermost
+func chain(base Doer, middleware []Middleware) Doer {
+	doer := base
+	for i := len(middleware) - 1; i >= 0; i-- {
+		doer = middleware[i](doer)
+	}
+	return doer
+}
+
+// Creates a Middleware that sets the given headers on every outgoing request, replacing existing values
+func HeaderMiddleware(header http.Header) Middleware {
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req = req.Clone(req.Context())
+			for name, values := range header {
+				req.Header[http.CanonicalHeaderKey(name)] = append([]string(nil), values...)
+			}
+			return next.Do(req)
+		})
+	}
+}
+
+// Creates a Middleware that writes every outgoing request and incoming response to w.
+// The API key and credential headers, plus any additional header or query parameter names given, are redacted.
+func DumpMiddleware(w io.Writer, redact ...string) Middleware {
+	names := append(append([]string(nil), redactedNames...), redact...)
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			dump, err := httputil.DumpRequestOut(redactRequest(req, names), true)
+			if err != nil {
+				return nil, fmt.Errorf("can't dump request: %w", err)
+			}
+			fmt.Fprintf(w, "%s\n", dump)
+
+			res, err := next.Do(req)
+			if err != nil {
+				return nil, err
+			}
+
+			dump, err = httputil.DumpResponse(res, true)
+			if err != nil {
+				res.Body.Close()
+				return nil, fmt.Errorf("can't dump response: %w", err)
+			}
+			fmt.Fprintf(w, "%s\n", redactHeaderLines(dump, names))
+
+			return res, nil
+		})
+	}
+}
+
+// Returns a copy of req with sensitive headers and query parameters redacted
+func redactRequest(req *http.Request, names []string) *http.Request {
+	clone := req.Clone(req.Context())
+	for _, name := range names {
+		if clone.Header.Get(name) != "" {
+			clone.Header.Set(name, redacted)
+		}
+	}
+	clone.URL = redactURL(clone.URL, names)
+	return clone
+}
+
+// Returns a copy of u with sensitive query parameters redacted
+func redactURL(u *url.URL, names []string) *url.URL {
+	clone := *u
+	query := clone.Query()
+	changed := false
+	for key := range query {
+		for _, name := range names {
+			if strings.EqualFold(key, name) {
+				query.Set(key, redacted)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		clone.RawQuery = query.Encode()
+	}
+	return &clone
+}
+
+// Redacts sensitive header lines in a dumped response
+func redactHeaderLines(dump []byte, names []string) []byte {
+	head, body, found := strings.Cut(string(dump), "\r\n\r\n")
+	lines := strings.Split(head, "\r\n")
+	for i, line := range lines {
+		name, _, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		for _, redactName := range names {
+			if strings.EqualFold(strings.TrimSpace(name), redactName) {
+				lines[i] = name + ": " + redacted
+			}
+		}
+	}
+	result := strings.Join(lines, "\r\n")
+	if found {
+		result += "\r\n\r\n" + body
+	}
+	return []byte(result)
+}
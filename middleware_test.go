@@ -0,0 +1,127 @@
+package holidays
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Run("runs in the order added", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		var calls []string
+		record := func(name string) Middleware {
+			return func(next Doer) Doer {
+				return DoerFunc(func(req *http.Request) (*http.Response, error) {
+					calls = append(calls, name+" request")
+					res, err := next.Do(req)
+					calls = append(calls, name+" response")
+					return res, err
+				})
+			}
+		}
+
+		api, _ := New("abc123", WithMiddleware(record("first"), record("second")), WithMiddleware(record("third")))
+		_, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{
+			"first request",
+			"second request",
+			"third request",
+			"third response",
+			"second response",
+			"first response",
+		}, calls)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("sees the API key", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		var apiKey string
+		api, _ := New("abc123", WithMiddleware(func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				apiKey = req.Header.Get("apikey")
+				return next.Do(req)
+			})
+		}))
+		api.GetEvents(GetEventsRequest{})
+
+		assert.Equal(t, "abc123", apiKey)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("injects headers", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchHeader("X-Team", "growth").
+			MatchHeader("User-Agent", "custom").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123", WithMiddleware(HeaderMiddleware(http.Header{
+			"x-team":     {"growth"},
+			"User-Agent": {"custom"},
+		})))
+		_, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("dumps requests and responses with secrets redacted", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Reply(200).
+			SetHeader("X-Secret", "shh").
+			SetHeader("x-ratelimit-remaining-month", "88").
+			File("testdata/getEvents-default.json")
+
+		var out bytes.Buffer
+		api, _ := New("abc123", WithMiddleware(DumpMiddleware(&out, "X-Secret")))
+		response, err := api.GetEvents(GetEventsRequest{})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "America/Chicago", response.Timezone)
+		assert.Equal(t, 88, response.RateLimit.RemainingMonth)
+		assert.Contains(t, out.String(), "GET /checkiday/events?adult=false HTTP/1.1")
+		assert.Contains(t, out.String(), "Apikey: REDACTED")
+		assert.Contains(t, out.String(), "X-Secret: REDACTED")
+		assert.Contains(t, out.String(), "Cinco de Mayo")
+		assert.NotContains(t, out.String(), "abc123")
+		assert.NotContains(t, out.String(), "shh")
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("redacts query parameters", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "https://example.com/?apikey=abc123&date=today", nil)
+
+		redactedReq := redactRequest(req, redactedNames)
+
+		assert.Equal(t, "apikey=REDACTED&date=today", redactedReq.URL.RawQuery)
+		assert.Equal(t, "apikey=abc123&date=today", req.URL.RawQuery)
+	})
+}
@@ -1,12 +1,41 @@
 package holidays
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
 // An interface of the API's standard response
 type StandardResponseInterface interface {
 }
 
 // The API's standard response
 type StandardResponse struct {
-	RateLimit RateLimit // The API plan's current rate limit and status
+	RateLimit RateLimit                  // The API plan's current rate limit and status
+	Extra     map[string]json.RawMessage `json:"-"` // Response fields the Client doesn't know, by path. Only collected in DecodeLenient mode.
+	Raw       *RawResponse               `json:"-"` // The raw HTTP response. Only kept when the Client is created WithRawResponse.
+	Metadata  ResponseMetadata           `json:"-"` // Details about how the response was fetched
+}
+
+// Details about how a response was fetched, for correlating incidents with upstream support
+type ResponseMetadata struct {
+	RequestId string        // The upstream request ID header, if present
+	Latency   time.Duration // The round-trip time until the response headers arrived
+	FinalUrl  string        // The URL after following redirects, with any API key redacted
+}
+
+// The raw HTTP response behind a decoded response
+type RawResponse struct {
+	StatusCode int         // The HTTP status code
+	Header     http.Header // The response headers
+	Body       []byte      // The exact response body
+	Url        string      // The request URL, with any API key redacted
+}
+
+func (r *StandardResponse) standard() *StandardResponse {
+	return r
 }
 
 // Your API plan's current Rate Limit and status. Upgrade to increase these limits.
@@ -17,9 +46,10 @@ type RateLimit struct {
 
 // The Request struct for calling GetEvents
 type GetEventsRequest struct {
-	Date     string // Date to get the events for. Defaults to today.
-	Adult    bool   // Include events that may be unsafe for viewing at work or by children. Default is false.
-	Timezone string // IANA Time Zone for calculating dates and times. Defaults to America/Chicago.
+	Date         string // Date to get the events for. Defaults to today.
+	Adult        bool   // Include events that may be unsafe for viewing at work or by children. Defaults to the Client's default, or false.
+	ExcludeAdult bool   // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
+	Timezone     string // IANA Time Zone for calculating dates and times. Defaults to the Client's default, or America/Chicago.
 }
 
 // The Response struct returned by GetEvents
@@ -42,8 +72,9 @@ type EventSummary struct {
 
 // The Request struct for calling Search
 type SearchRequest struct {
-	Query string // The search query. Must be at least 3 characters long.
-	Adult bool   // Include events that may be unsafe for viewing at work or by children. Default is false.
+	Query        string // The search query. Must be at least 3 characters long.
+	Adult        bool   // Include events that may be unsafe for viewing at work or by children. Defaults to the Client's default, or false.
+	ExcludeAdult bool   // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
 }
 
 // The Response struct returned by Search
@@ -57,8 +88,8 @@ type SearchResponse struct {
 // The Request struct for calling GetEventInfo
 type GetEventInfoRequest struct {
 	Id    string `json:"id"`    // The ID of the requested Event.
-	Start int    `json:"start"` // The starting range of returned occurrences. Optional, defaults to 2 years prior.
-	End   int    `json:"end"`   // The ending range of returned occurrences. Optional, defaults to 3 years in the future.
+	Start int    `json:"start"` // The starting range of returned occurrences. Optional, defaults to the Client's default, or 2 years prior.
+	End   int    `json:"end"`   // The ending range of returned occurrences. Optional, defaults to the Client's default, or 3 years in the future.
 }
 
 // The Response struct returned by GetEventInfo
@@ -69,12 +100,17 @@ type GetEventInfoResponse struct {
 
 // Information about an Event's Pattern
 type Pattern struct {
-	FirstYear        int    `json:"first_year"`        // The first year this event is observed (0 implies none or unknown)
-	LastYear         int    `json:"last_year"`         // The last year this event is observed (0 implies none or unknown)
-	Observed         string `json:"observed"`          // A description of how this event is observed (formatted as plain text)
-	ObservedHtml     string `json:"observed_html"`     // A description of how this event is observed (formatted as HTML)
-	ObservedMarkdown string `json:"observed_markdown"` // A description of how this event is observed (formatted as Markdown)
-	Length           int    `json:"length"`            // For how many days this event is celebrated
+	FirstYear        Optional[int] `json:"first_year"`        // The first year this event is observed (unset if unknown)
+	LastYear         Optional[int] `json:"last_year"`         // The last year this event is observed (unset if still observed)
+	Observed         string        `json:"observed"`          // A description of how this event is observed (formatted as plain text)
+	ObservedHtml     string        `json:"observed_html"`     // A description of how this event is observed (formatted as HTML)
+	ObservedMarkdown string        `json:"observed_markdown"` // A description of how this event is observed (formatted as Markdown)
+	Length           int           `json:"length"`            // For how many days this event is celebrated
+}
+
+// Reports whether this Pattern is in effect in the given year
+func (p Pattern) ActiveIn(year int) bool {
+	return inYears(year, p.FirstYear, p.LastYear)
 }
 
 // Information about an Event's Occurrence
@@ -83,6 +119,37 @@ type Occurrence struct {
 	Length int    `json:"length"` // The length (in days) of the Event occurrence
 }
 
+// The layout of API dates, like Occurrence and GetEventsRequest dates
+const DateLayout = "01/02/2006"
+
+// Gets the IANA Time Zone name of loc to send to the API, or "" if it has none, like time.Local and fixed zones.
+// Requests without a Timezone fall back to America/Chicago: an explicit Date still selects the right day,
+// but Events given as timestamps are calculated in America/Chicago rather than in loc.
+func TimezoneName(loc *time.Location) string {
+	if loc == nil || loc == time.Local {
+		return ""
+	}
+	name := loc.String()
+	if name == "Local" {
+		return ""
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+	return name
+}
+
+// Parses the Occurrence's date or timestamp. Dates are returned as midnight UTC.
+func (o Occurrence) Time() (time.Time, error) {
+	if t, err := time.Parse(DateLayout, o.Date); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, o.Date); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("can't parse occurrence date %q", o.Date)
+}
+
 // Formatted Text
 type RichText struct {
 	Text     string `json:"text"`     // Formatted as plain text
@@ -92,9 +159,17 @@ type RichText struct {
 
 // Information about an Event's Alternate Name
 type AlternateName struct {
-	Name      string `json:"name"`       // An Event's Alternate Name
-	FirstYear int    `json:"first_year"` // The first year this Alternate Name was in effect (0 implies none or unknown)
-	LastYear  int    `json:"last_year"`  // The last year this Alternate Name was in effect (0 implies none or unknown)
+	Name      string        `json:"name"`       // An Event's Alternate Name
+	FirstYear Optional[int] `json:"first_year"` // The first year this Alternate Name was in effect (unset if unknown)
+	LastYear  Optional[int] `json:"last_year"`  // The last year this Alternate Name was in effect (unset if still in effect)
+}
+
+// Gets this Alternate Name if it was in effect in the given year
+func (a AlternateName) NameIn(year int) (string, bool) {
+	if !inYears(year, a.FirstYear, a.LastYear) {
+		return "", false
+	}
+	return a.Name, true
 }
 
 // Information about an Event image
@@ -126,6 +201,17 @@ type EventInfo struct {
 	Founders       []FounderInfo   `json:"founders"`        // The Event's founders
 }
 
+// Reports whether year falls within an inclusive range whose unset ends are unbounded
+func inYears(year int, first, last Optional[int]) bool {
+	if first, ok := first.Get(); ok && year < first {
+		return false
+	}
+	if last, ok := last.Get(); ok && year > last {
+		return false
+	}
+	return true
+}
+
 // An Error response object
 type errorResponse struct {
 	Error string `json:"error"` // A descriptive error message
@@ -0,0 +1,83 @@
+package holidays
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPattern(t *testing.T) {
+	t.Run("decodes nullable years", func(t *testing.T) {
+		b, _ := os.ReadFile("testdata/getEventInfo.json")
+		var response GetEventInfoResponse
+
+		err := json.Unmarshal(b, &response)
+
+		assert.Nil(t, err)
+		assert.Equal(t, Some(2002), response.Event.Patterns[0].FirstYear)
+		assert.False(t, response.Event.Patterns[0].LastYear.IsSet())
+		assert.Equal(t, AlternateName{Name: "TEST", FirstYear: Some(2005)}, response.Event.AlternateNames[0])
+	})
+
+	t.Run("is active within its years", func(t *testing.T) {
+		pattern := Pattern{FirstYear: Some(2002), LastYear: Some(2010)}
+
+		assert.False(t, pattern.ActiveIn(2001))
+		assert.True(t, pattern.ActiveIn(2002))
+		assert.True(t, pattern.ActiveIn(2010))
+		assert.False(t, pattern.ActiveIn(2011))
+	})
+
+	t.Run("is active indefinitely without years", func(t *testing.T) {
+		ongoing := Pattern{FirstYear: Some(2002)}
+		assert.False(t, ongoing.ActiveIn(2001))
+		assert.True(t, ongoing.ActiveIn(3000))
+
+		unknownStart := Pattern{LastYear: Some(2010)}
+		assert.True(t, unknownStart.ActiveIn(1000))
+		assert.False(t, unknownStart.ActiveIn(2011))
+	})
+}
+
+func TestAlternateName(t *testing.T) {
+	t.Run("is named within its years", func(t *testing.T) {
+		alternateName := AlternateName{Name: "TEST", FirstYear: Some(2005)}
+
+		name, ok := alternateName.NameIn(2004)
+		assert.Equal(t, "", name)
+		assert.False(t, ok)
+
+		name, ok = alternateName.NameIn(2005)
+		assert.Equal(t, "TEST", name)
+		assert.True(t, ok)
+	})
+}
+
+func TestOccurrence(t *testing.T) {
+	t.Run("parses dates", func(t *testing.T) {
+		occurrence, err := Occurrence{Date: "08/08/2020"}.Time()
+
+		assert.Nil(t, err)
+		assert.Equal(t, time.Date(2020, time.August, 8, 0, 0, 0, 0, time.UTC), occurrence)
+	})
+
+	t.Run("parses timestamps", func(t *testing.T) {
+		occurrence, err := Occurrence{Date: "2020-08-08T10:00:00-05:00"}.Time()
+
+		assert.Nil(t, err)
+		assert.True(t, time.Date(2020, time.August, 8, 15, 0, 0, 0, time.UTC).Equal(occurrence))
+	})
+}
+
+func TestTimezoneName(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	assert.Equal(t, "Asia/Tokyo", TimezoneName(tokyo))
+	assert.Equal(t, "UTC", TimezoneName(time.UTC))
+	assert.Equal(t, "", TimezoneName(time.Local))
+	assert.Equal(t, "", TimezoneName(time.FixedZone("UTC+9", 9*60*60)))
+	assert.Equal(t, "", TimezoneName(nil))
+}
@@ -0,0 +1,73 @@
+package holidays
+
+import (
+	"time"
+)
+
+// An Occurrence with the name the Event had when it occurred
+type NamedOccurrence struct {
+	Occurrence
+	Name string // The Event's name in the year of the Occurrence
+}
+
+// Gets the Event's name on the given date.
+// An Alternate Name that has ended and was in effect that year is a historical name and takes precedence.
+// Alternate Names that are still in effect are aliases, so the Event's Name is used instead.
+func (e EventInfo) NameOn(date time.Time) string {
+	return e.nameIn(date.Year())
+}
+
+// Gets every name the Event had between from and to (inclusive, by year), starting with the earliest.
+// Aliases in effect during the range are included after the name they accompany.
+func (e EventInfo) NamesDuring(from, to time.Time) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	for year := from.Year(); year <= to.Year(); year++ {
+		add(e.nameIn(year))
+		for _, alternateName := range e.AlternateNames {
+			if alternateName.LastYear.IsSet() {
+				continue
+			}
+			if name, ok := alternateName.NameIn(year); ok {
+				add(name)
+			}
+		}
+	}
+
+	return names
+}
+
+// Gets the Event's Occurrences, each with the name the Event had that year
+func (e EventInfo) NamedOccurrences() ([]NamedOccurrence, error) {
+	occurrences := make([]NamedOccurrence, len(e.Occurrences))
+	for i, occurrence := range e.Occurrences {
+		t, err := occurrence.Time()
+		if err != nil {
+			return nil, err
+		}
+		occurrences[i] = NamedOccurrence{
+			Occurrence: occurrence,
+			Name:       e.NameOn(t),
+		}
+	}
+	return occurrences, nil
+}
+
+func (e EventInfo) nameIn(year int) string {
+	for _, alternateName := range e.AlternateNames {
+		if !alternateName.LastYear.IsSet() {
+			continue
+		}
+		if name, ok := alternateName.NameIn(year); ok {
+			return name
+		}
+	}
+	return e.Name
+}
@@ -0,0 +1,63 @@
+package holidays
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	event := EventInfo{
+		EventSummary: EventSummary{Name: "Sibling Day"},
+		AlternateNames: []AlternateName{
+			{Name: "Brothers Day", FirstYear: Some(1990), LastYear: Some(1999)},
+			{Name: "Siblings Day", FirstYear: Some(2005)},
+		},
+		Occurrences: []Occurrence{
+			{Date: "04/10/1999", Length: 1},
+			{Date: "04/10/2000", Length: 1},
+		},
+	}
+
+	date := func(year int) time.Time {
+		return time.Date(year, time.April, 10, 0, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("picks the historical name", func(t *testing.T) {
+		assert.Equal(t, "Sibling Day", event.NameOn(date(1989)))
+		assert.Equal(t, "Brothers Day", event.NameOn(date(1990)))
+		assert.Equal(t, "Brothers Day", event.NameOn(date(1999)))
+		assert.Equal(t, "Sibling Day", event.NameOn(date(2000)))
+	})
+
+	t.Run("keeps the name over aliases", func(t *testing.T) {
+		assert.Equal(t, "Sibling Day", event.NameOn(date(2010)))
+	})
+
+	t.Run("lists names during a range", func(t *testing.T) {
+		assert.Equal(t, []string{"Brothers Day"}, event.NamesDuring(date(1991), date(1992)))
+		assert.Equal(t, []string{"Brothers Day", "Sibling Day"}, event.NamesDuring(date(1995), date(2004)))
+		assert.Equal(t, []string{"Sibling Day", "Siblings Day"}, event.NamesDuring(date(2000), date(2010)))
+		assert.Empty(t, event.NamesDuring(date(2010), date(2000)))
+	})
+
+	t.Run("names occurrences", func(t *testing.T) {
+		occurrences, err := event.NamedOccurrences()
+
+		assert.Nil(t, err)
+		assert.Equal(t, []NamedOccurrence{
+			{Occurrence: Occurrence{Date: "04/10/1999", Length: 1}, Name: "Brothers Day"},
+			{Occurrence: Occurrence{Date: "04/10/2000", Length: 1}, Name: "Sibling Day"},
+		}, occurrences)
+	})
+
+	t.Run("fails on invalid occurrences", func(t *testing.T) {
+		invalid := EventInfo{Occurrences: []Occurrence{{Date: "someday"}}}
+
+		occurrences, err := invalid.NamedOccurrences()
+
+		assert.Nil(t, occurrences)
+		assert.EqualError(t, err, "can't parse occurrence date \"someday\"")
+	})
+}
@@ -0,0 +1,257 @@
+// Package notifier posts a daily "Today is ..." message for each configured time zone.
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"text/template"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// The subset of *holidays.Client used by the Notifier
+type Client interface {
+	GetEvents(req holidays.GetEventsRequest) (*holidays.GetEventsResponse, error)
+	GetEventInfo(req holidays.GetEventInfoRequest) (*holidays.GetEventInfoResponse, error)
+}
+
+// When to notify: every day at Hour:Minute in Location
+type Schedule struct {
+	Location *time.Location // The time zone to notify in. Defaults to UTC.
+	Hour     int            // The hour of the day (0-23)
+	Minute   int            // The minute of the hour (0-59)
+}
+
+// The Notifier configuration
+type Config struct {
+	Client    Client             // The API Client. Required.
+	Sinks     map[string]Sink    // Where to deliver messages, by name. The name is used to remember what was sent. Required.
+	Schedules []Schedule         // When to notify. Required.
+	Store     Store              // Remembers what was already sent. Defaults to an in-memory Store.
+	Template  *template.Template // Renders the message text from TemplateData. Defaults to DefaultTemplate.
+	Enrich    bool               // Whether to fetch each Event's EventInfo (image, description, ...)
+	Adult     bool               // Include events that may be unsafe for viewing at work or by children
+	OnError   func(err error)    // Called with errors encountered by Run. Optional.
+}
+
+// The data passed to the message Template
+type TemplateData struct {
+	Date     string  // The local date, formatted as MM/DD/YYYY
+	Timezone string  // The IANA Time Zone of the date, empty if the Location has none
+	Events   []Event // The Events observed on the date, including multi-day Events starting that day
+}
+
+// An Event included in a notification
+type Event struct {
+	holidays.EventSummary
+	Info *holidays.EventInfo // The Event Info, only set when Config.Enrich is true
+}
+
+// A rendered notification
+type Message struct {
+	Subject  string  // A short subject line
+	Text     string  // The rendered message text
+	Date     string  // The local date, formatted as MM/DD/YYYY
+	Timezone string  // The IANA Time Zone of the date, empty if the Location has none
+	Events   []Event // The Events the message is about
+}
+
+// The default message Template
+var DefaultTemplate = template.Must(template.New("message").Parse(
+	`{{range .Events}}Today is {{.Name}}! {{.Url}}
+{{end}}`))
+
+// Posts daily holiday messages
+type Notifier struct {
+	config Config
+	now    func() time.Time
+}
+
+// Creates a new Notifier
+func New(config Config) (*Notifier, error) {
+	if config.Client == nil {
+		return nil, errors.New("client is required")
+	}
+	if len(config.Sinks) == 0 {
+		return nil, errors.New("at least one sink is required")
+	}
+	if len(config.Schedules) == 0 {
+		return nil, errors.New("at least one schedule is required")
+	}
+	// copy the Schedules so filling in defaults doesn't change the caller's slice
+	config.Schedules = append([]Schedule(nil), config.Schedules...)
+	for i, s := range config.Schedules {
+		if s.Hour < 0 || s.Hour > 23 || s.Minute < 0 || s.Minute > 59 {
+			return nil, fmt.Errorf("schedule %d has an invalid time %02d:%02d", i, s.Hour, s.Minute)
+		}
+		if s.Location == nil {
+			config.Schedules[i].Location = time.UTC
+		}
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryStore()
+	}
+	if config.Template == nil {
+		config.Template = DefaultTemplate
+	}
+
+	return &Notifier{
+		config: config,
+		now:    time.Now,
+	}, nil
+}
+
+// Notifies on every Schedule until ctx is done.
+// Notifications whose time already passed today are sent immediately unless they were already sent.
+func (n *Notifier) Run(ctx context.Context) error {
+	now := n.now()
+	for _, s := range n.config.Schedules {
+		if today := s.at(now); !now.Before(today) {
+			n.report(n.Notify(ctx, today))
+		}
+	}
+
+	for {
+		now := n.now()
+		var next time.Time
+		for _, s := range n.config.Schedules {
+			if t := s.next(now); next.IsZero() || t.Before(next) {
+				next = t
+			}
+		}
+
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		for _, s := range n.config.Schedules {
+			if t := s.at(next); t.Equal(next) {
+				n.report(n.Notify(ctx, t))
+			}
+		}
+	}
+}
+
+// Notifies every Sink about the Events on day's date in day's Location, skipping Sinks that were already notified
+func (n *Notifier) Notify(ctx context.Context, day time.Time) error {
+	date := day.Format(holidays.DateLayout)
+	zone := zoneKey(day)
+
+	var pending []string
+	for name := range n.config.Sinks {
+		sent, err := n.config.Store.Sent(key(zone, date, name))
+		if err != nil {
+			return fmt.Errorf("can't check sent status: %w", err)
+		}
+		if !sent {
+			pending = append(pending, name)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	sort.Strings(pending)
+
+	msg, err := n.Message(day)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, name := range pending {
+		if err := n.config.Sinks[name].Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("can't send to %s: %w", name, err))
+			continue
+		}
+		if err := n.config.Store.MarkSent(key(zone, date, name)); err != nil {
+			errs = append(errs, fmt.Errorf("can't mark %s as sent: %w", name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Builds the Message for day's date in day's Location without sending it
+func (n *Notifier) Message(day time.Time) (Message, error) {
+	date := day.Format(holidays.DateLayout)
+	timezone := holidays.TimezoneName(day.Location())
+
+	res, err := n.config.Client.GetEvents(holidays.GetEventsRequest{
+		Date:     date,
+		Timezone: timezone,
+		Adult:    n.config.Adult,
+	})
+	if err != nil {
+		return Message{}, fmt.Errorf("can't get events: %w", err)
+	}
+
+	var events []Event
+	for _, summary := range append(append([]holidays.EventSummary(nil), res.Events...), res.MultidayStarting...) {
+		event := Event{EventSummary: summary}
+		if n.config.Enrich {
+			info, err := n.config.Client.GetEventInfo(holidays.GetEventInfoRequest{Id: summary.Id})
+			if err != nil {
+				return Message{}, fmt.Errorf("can't get event info for %s: %w", summary.Id, err)
+			}
+			event.Info = &info.Event
+		}
+		events = append(events, event)
+	}
+
+	var text bytes.Buffer
+	data := TemplateData{Date: date, Timezone: timezone, Events: events}
+	if err := n.config.Template.Execute(&text, data); err != nil {
+		return Message{}, fmt.Errorf("can't render message: %w", err)
+	}
+
+	return Message{
+		Subject:  "Holidays for " + date,
+		Text:     text.String(),
+		Date:     date,
+		Timezone: timezone,
+		Events:   events,
+	}, nil
+}
+
+func (n *Notifier) report(err error) {
+	if err != nil && n.config.OnError != nil {
+		n.config.OnError(err)
+	}
+}
+
+// The Store key remembering that a Sink was notified for a date
+func key(zone, date, sink string) string {
+	return zone + " " + date + " " + sink
+}
+
+// Identifies day's time zone in Store keys: its IANA name, or its UTC offset if it has none
+func zoneKey(day time.Time) string {
+	if name := holidays.TimezoneName(day.Location()); name != "" {
+		return name
+	}
+	return "UTC" + day.Format("-07:00")
+}
+
+// Returns the Schedule's time on now's date in the Schedule's Location
+func (s Schedule) at(now time.Time) time.Time {
+	local := now.In(s.Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), s.Hour, s.Minute, 0, 0, s.Location)
+}
+
+// Returns the first Schedule time after now
+func (s Schedule) next(now time.Time) time.Time {
+	t := s.at(now)
+	if t.After(now) {
+		return t
+	}
+	local := now.In(s.Location)
+	return time.Date(local.Year(), local.Month(), local.Day()+1, s.Hour, s.Minute, 0, 0, s.Location)
+}
@@ -0,0 +1,236 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+type fakeClient struct {
+	requests []holidays.GetEventsRequest
+	infos    []string
+	err      error
+}
+
+func (c *fakeClient) GetEvents(req holidays.GetEventsRequest) (*holidays.GetEventsResponse, error) {
+	c.requests = append(c.requests, req)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &holidays.GetEventsResponse{
+		Date:     req.Date,
+		Timezone: req.Timezone,
+		Events: []holidays.EventSummary{
+			{Id: "pizza", Name: "National Pizza Day", Url: "https://www.checkiday.com/pizza/national-pizza-day"},
+		},
+		MultidayStarting: []holidays.EventSummary{
+			{Id: "week", Name: "Pizza Week", Url: "https://www.checkiday.com/week/pizza-week"},
+		},
+		MultidayOngoing: []holidays.EventSummary{
+			{Id: "month", Name: "Pizza Month", Url: "https://www.checkiday.com/month/pizza-month"},
+		},
+	}, nil
+}
+
+func (c *fakeClient) GetEventInfo(req holidays.GetEventInfoRequest) (*holidays.GetEventInfoResponse, error) {
+	c.infos = append(c.infos, req.Id)
+	return &holidays.GetEventInfoResponse{
+		Event: holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Id: req.Id},
+			Description:  holidays.RichText{Text: "About " + req.Id},
+		},
+	}, nil
+}
+
+type failingSink struct{}
+
+func (failingSink) Send(ctx context.Context, msg Message) error {
+	return errors.New("offline")
+}
+
+func TestNew(t *testing.T) {
+	t.Run("validates the config", func(t *testing.T) {
+		sinks := map[string]Sink{"out": &WriterSink{W: &bytes.Buffer{}}}
+		schedules := []Schedule{{Hour: 8}}
+
+		_, err := New(Config{Sinks: sinks, Schedules: schedules})
+		assert.EqualError(t, err, "client is required")
+
+		_, err = New(Config{Client: &fakeClient{}, Schedules: schedules})
+		assert.EqualError(t, err, "at least one sink is required")
+
+		_, err = New(Config{Client: &fakeClient{}, Sinks: sinks})
+		assert.EqualError(t, err, "at least one schedule is required")
+
+		_, err = New(Config{Client: &fakeClient{}, Sinks: sinks, Schedules: []Schedule{{Hour: 24}}})
+		assert.EqualError(t, err, "schedule 0 has an invalid time 24:00")
+	})
+
+	t.Run("doesn't change the caller's schedules", func(t *testing.T) {
+		schedules := []Schedule{{Hour: 8}}
+
+		n, err := New(Config{Client: &fakeClient{}, Sinks: map[string]Sink{"out": &WriterSink{W: &bytes.Buffer{}}}, Schedules: schedules})
+
+		assert.Nil(t, err)
+		assert.Nil(t, schedules[0].Location)
+		assert.Equal(t, time.UTC, n.config.Schedules[0].Location)
+	})
+}
+
+func TestNotify(t *testing.T) {
+	chicago, _ := time.LoadLocation("America/Chicago")
+	day := time.Date(2025, 2, 9, 8, 0, 0, 0, chicago)
+
+	t.Run("sends the local date's events", func(t *testing.T) {
+		client := &fakeClient{}
+		var out bytes.Buffer
+		n, _ := New(Config{
+			Client:    client,
+			Sinks:     map[string]Sink{"out": &WriterSink{W: &out}},
+			Schedules: []Schedule{{Location: chicago, Hour: 8}},
+		})
+
+		err := n.Notify(context.Background(), day)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []holidays.GetEventsRequest{{Date: "02/09/2025", Timezone: "America/Chicago"}}, client.requests)
+		assert.Equal(t, "Today is National Pizza Day! https://www.checkiday.com/pizza/national-pizza-day\n"+
+			"Today is Pizza Week! https://www.checkiday.com/week/pizza-week\n", out.String())
+	})
+
+	t.Run("leaves out time zones without an IANA name", func(t *testing.T) {
+		for _, loc := range []*time.Location{time.Local, time.FixedZone("UTC+9", 9*60*60)} {
+			client := &fakeClient{}
+			store := NewMemoryStore()
+			n, _ := New(Config{
+				Client:    client,
+				Sinks:     map[string]Sink{"out": &WriterSink{W: &bytes.Buffer{}}},
+				Schedules: []Schedule{{Location: loc, Hour: 8}},
+				Store:     store,
+			})
+			local := time.Date(2025, 2, 9, 8, 0, 0, 0, loc)
+
+			err := n.Notify(context.Background(), local)
+
+			assert.Nil(t, err)
+			assert.Equal(t, []holidays.GetEventsRequest{{Date: "02/09/2025"}}, client.requests)
+			sent, _ := store.Sent(key("UTC"+local.Format("-07:00"), "02/09/2025", "out"))
+			assert.True(t, sent)
+		}
+	})
+
+	t.Run("does not send twice", func(t *testing.T) {
+		client := &fakeClient{}
+		var out bytes.Buffer
+		store := NewMemoryStore()
+		config := Config{
+			Client:    client,
+			Sinks:     map[string]Sink{"out": &WriterSink{W: &out}},
+			Schedules: []Schedule{{Location: chicago, Hour: 8}},
+			Store:     store,
+		}
+
+		n, _ := New(config)
+		n.Notify(context.Background(), day)
+		restarted, _ := New(config)
+		restarted.Notify(context.Background(), day)
+
+		assert.Len(t, client.requests, 1)
+		assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("\n")))
+	})
+
+	t.Run("retries only failed sinks", func(t *testing.T) {
+		client := &fakeClient{}
+		var out bytes.Buffer
+		store := NewMemoryStore()
+		n, _ := New(Config{
+			Client:    client,
+			Sinks:     map[string]Sink{"out": &WriterSink{W: &out}, "webhook": failingSink{}},
+			Schedules: []Schedule{{Location: chicago, Hour: 8}},
+			Store:     store,
+		})
+
+		err := n.Notify(context.Background(), day)
+		assert.EqualError(t, err, "can't send to webhook: offline")
+
+		n.Notify(context.Background(), day)
+		assert.Len(t, client.requests, 2)
+		assert.Equal(t, 2, bytes.Count(out.Bytes(), []byte("\n")))
+	})
+
+	t.Run("enriches with event info", func(t *testing.T) {
+		client := &fakeClient{}
+		var out bytes.Buffer
+		n, _ := New(Config{
+			Client:    client,
+			Sinks:     map[string]Sink{"out": &WriterSink{W: &out}},
+			Schedules: []Schedule{{Location: chicago, Hour: 8}},
+			Enrich:    true,
+			Template:  template.Must(template.New("").Parse(`{{range .Events}}{{.Info.Description.Text}};{{end}}`)),
+		})
+
+		err := n.Notify(context.Background(), day)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"pizza", "week"}, client.infos)
+		assert.Equal(t, "About pizza;About week;", out.String())
+	})
+
+	t.Run("passes along errors", func(t *testing.T) {
+		n, _ := New(Config{
+			Client:    &fakeClient{err: errors.New("MyError!")},
+			Sinks:     map[string]Sink{"out": &WriterSink{W: &bytes.Buffer{}}},
+			Schedules: []Schedule{{Hour: 8}},
+		})
+
+		err := n.Notify(context.Background(), day)
+
+		assert.EqualError(t, err, "can't get events: MyError!")
+	})
+}
+
+func TestRun(t *testing.T) {
+	t.Run("catches up on missed notifications", func(t *testing.T) {
+		tokyo, _ := time.LoadLocation("Asia/Tokyo")
+		client := &fakeClient{}
+		var out bytes.Buffer
+		n, _ := New(Config{
+			Client: client,
+			Sinks:  map[string]Sink{"out": &WriterSink{W: &out}},
+			Schedules: []Schedule{
+				{Location: tokyo, Hour: 8},
+				{Location: time.UTC, Hour: 8},
+			},
+		})
+		// 9:00 in Tokyo, 0:00 in UTC
+		n.now = func() time.Time { return time.Date(2025, 2, 9, 0, 0, 0, 0, time.UTC) }
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := n.Run(ctx)
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []holidays.GetEventsRequest{{Date: "02/09/2025", Timezone: "Asia/Tokyo"}}, client.requests)
+	})
+}
+
+func TestSchedule(t *testing.T) {
+	t.Run("finds the next time", func(t *testing.T) {
+		chicago, _ := time.LoadLocation("America/Chicago")
+		s := Schedule{Location: chicago, Hour: 8, Minute: 30}
+
+		before := time.Date(2025, 3, 8, 8, 0, 0, 0, chicago)
+		assert.Equal(t, time.Date(2025, 3, 8, 8, 30, 0, 0, chicago), s.next(before))
+
+		// crosses the daylight saving time change
+		after := time.Date(2025, 3, 8, 9, 0, 0, 0, chicago)
+		assert.Equal(t, time.Date(2025, 3, 9, 8, 30, 0, 0, chicago), s.next(after))
+		assert.Equal(t, 22*time.Hour+30*time.Minute, s.next(after).Sub(after))
+	})
+}
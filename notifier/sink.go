@@ -0,0 +1,126 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"os"
+	"strings"
+)
+
+// A destination for notification Messages
+type Sink interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// A Sink that writes Message text to a Writer
+type WriterSink struct {
+	W io.Writer
+}
+
+// Creates a Sink that writes Message text to standard output
+func StdoutSink() *WriterSink {
+	return &WriterSink{W: os.Stdout}
+}
+
+// Writes the Message text
+func (s *WriterSink) Send(ctx context.Context, msg Message) error {
+	_, err := io.WriteString(s.W, msg.Text)
+	return err
+}
+
+// A Sink that POSTs each Message as JSON to a webhook.
+// The message text is sent in the "text" field, which Slack-compatible incoming webhooks display.
+type WebhookSink struct {
+	Url    string       // The webhook URL. Required.
+	Header http.Header  // Additional request headers. Optional.
+	Client *http.Client // The HTTP Client to use. Defaults to http.DefaultClient.
+}
+
+// The JSON body POSTed by a WebhookSink
+type webhookPayload struct {
+	Text     string  `json:"text"`
+	Subject  string  `json:"subject"`
+	Date     string  `json:"date"`
+	Timezone string  `json:"timezone"`
+	Events   []Event `json:"events"`
+}
+
+// POSTs the Message
+func (s *WebhookSink) Send(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(webhookPayload{
+		Text:     msg.Text,
+		Subject:  msg.Subject,
+		Date:     msg.Date,
+		Timezone: msg.Timezone,
+		Events:   msg.Events,
+	})
+	if err != nil {
+		return fmt.Errorf("can't encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.Url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can't create request: %w", err)
+	}
+	for name, values := range s.Header {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can't process request: %w", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", res.Status)
+	}
+
+	return nil
+}
+
+// A Sink that emails each Message
+type SMTPSink struct {
+	Addr string    // The SMTP server address, e.g. "smtp.example.com:587". Required.
+	Auth smtp.Auth // The SMTP authentication. Optional.
+	From string    // The sender address. Required.
+	To   []string  // The recipient addresses. Required.
+
+	sendMail func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// Emails the Message
+func (s *SMTPSink) Send(ctx context.Context, msg Message) error {
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "From: %s\r\n", s.From)
+	fmt.Fprintf(&body, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&body, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&body, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&body, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(&body, "\r\n")
+	body.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Text, "\r\n", "\n"), "\n", "\r\n"))
+
+	sendMail := s.sendMail
+	if sendMail == nil {
+		sendMail = smtp.SendMail
+	}
+
+	if err := sendMail(s.Addr, s.Auth, s.From, s.To, body.Bytes()); err != nil {
+		return fmt.Errorf("can't send mail: %w", err)
+	}
+
+	return nil
+}
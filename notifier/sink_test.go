@@ -0,0 +1,87 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+var message = Message{
+	Subject:  "Holidays for 02/09/2025",
+	Text:     "Today is National Pizza Day!\n",
+	Date:     "02/09/2025",
+	Timezone: "America/Chicago",
+	Events: []Event{
+		{EventSummary: holidays.EventSummary{Id: "pizza", Name: "National Pizza Day"}},
+	},
+}
+
+func TestWebhookSink(t *testing.T) {
+	t.Run("posts the message", func(t *testing.T) {
+		var payload map[string]any
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header = r.Header
+			json.NewDecoder(r.Body).Decode(&payload)
+		}))
+		defer server.Close()
+
+		sink := &WebhookSink{Url: server.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+		err := sink.Send(context.Background(), message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "application/json", header.Get("Content-Type"))
+		assert.Equal(t, "Bearer token", header.Get("Authorization"))
+		assert.Equal(t, "Today is National Pizza Day!\n", payload["text"])
+		assert.Equal(t, "02/09/2025", payload["date"])
+	})
+
+	t.Run("fails on error responses", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		sink := &WebhookSink{Url: server.URL}
+		err := sink.Send(context.Background(), message)
+
+		assert.EqualError(t, err, "webhook responded 403 Forbidden")
+	})
+}
+
+func TestSMTPSink(t *testing.T) {
+	t.Run("emails the message", func(t *testing.T) {
+		var addr, from string
+		var to []string
+		var body []byte
+		sink := &SMTPSink{
+			Addr: "smtp.example.com:587",
+			From: "bot@example.com",
+			To:   []string{"a@example.com", "b@example.com"},
+			sendMail: func(a string, auth smtp.Auth, f string, t []string, msg []byte) error {
+				addr, from, to, body = a, f, t, msg
+				return nil
+			},
+		}
+
+		err := sink.Send(context.Background(), message)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "smtp.example.com:587", addr)
+		assert.Equal(t, "bot@example.com", from)
+		assert.Equal(t, []string{"a@example.com", "b@example.com"}, to)
+		assert.Equal(t, "From: bot@example.com\r\n"+
+			"To: a@example.com, b@example.com\r\n"+
+			"Subject: Holidays for 02/09/2025\r\n"+
+			"MIME-Version: 1.0\r\n"+
+			"Content-Type: text/plain; charset=utf-8\r\n"+
+			"\r\n"+
+			"Today is National Pizza Day!\r\n", string(body))
+	})
+}
@@ -0,0 +1,116 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Remembers which notifications were already sent, so restarts do not send duplicates
+type Store interface {
+	Sent(key string) (bool, error)
+	MarkSent(key string) error
+}
+
+// A Store that only remembers for the life of the process
+type MemoryStore struct {
+	mu   sync.Mutex
+	sent map[string]bool
+}
+
+// Creates a new MemoryStore
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{sent: map[string]bool{}}
+}
+
+// Reports whether key was marked as sent
+func (s *MemoryStore) Sent(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sent[key], nil
+}
+
+// Marks key as sent
+func (s *MemoryStore) MarkSent(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent[key] = true
+	return nil
+}
+
+// A Store persisted to a JSON file
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+	sent map[string]time.Time
+}
+
+// Opens the FileStore at path, creating it on the first MarkSent if it does not exist
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path: path,
+		sent: map[string]time.Time{},
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't read store: %w", err)
+	}
+	if err := json.Unmarshal(b, &store.sent); err != nil {
+		return nil, fmt.Errorf("can't parse store: %w", err)
+	}
+
+	return store, nil
+}
+
+// Reports whether key was marked as sent
+func (s *FileStore) Sent(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.sent[key]
+	return ok, nil
+}
+
+// Marks key as sent and saves the file
+func (s *FileStore) MarkSent(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sent[key] = time.Now().UTC()
+
+	b, err := json.MarshalIndent(s.sent, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode store: %w", err)
+	}
+
+	// write to a temporary file first so a crash can't leave a truncated store
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("can't write store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return fmt.Errorf("can't write store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("can't write store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("can't write store: %w", err)
+	}
+
+	return nil
+}
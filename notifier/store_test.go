@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Run("persists sent keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sent.json")
+
+		store, err := NewFileStore(path)
+		assert.Nil(t, err)
+		sent, _ := store.Sent("a")
+		assert.False(t, sent)
+
+		assert.Nil(t, store.MarkSent("a"))
+
+		reopened, err := NewFileStore(path)
+		assert.Nil(t, err)
+		sent, _ = reopened.Sent("a")
+		assert.True(t, sent)
+		sent, _ = reopened.Sent("b")
+		assert.False(t, sent)
+	})
+
+	t.Run("fails on a corrupt file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "sent.json")
+		os.WriteFile(path, []byte("{"), 0o600)
+
+		_, err := NewFileStore(path)
+
+		assert.EqualError(t, err, "can't parse store: unexpected end of JSON input")
+	})
+}
@@ -0,0 +1,58 @@
+package holidays
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// A value that may be null or missing in an API response
+type Optional[T any] struct {
+	value T
+	set   bool
+}
+
+// Creates an Optional holding value
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// Reports whether the Optional holds a value
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// Gets the value and whether it is set
+func (o Optional[T]) Get() (T, bool) {
+	return o.value, o.set
+}
+
+// Gets the value, or fallback if it is not set
+func (o Optional[T]) OrElse(fallback T) T {
+	if !o.set {
+		return fallback
+	}
+	return o.value
+}
+
+// Encodes the value, or null if it is not set
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if !o.set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(o.value)
+}
+
+// Decodes the value, leaving the Optional unset for null
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*o = Optional[T]{}
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	*o = Some(value)
+	return nil
+}
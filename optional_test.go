@@ -0,0 +1,54 @@
+package holidays
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptional(t *testing.T) {
+	t.Run("decodes values and nulls", func(t *testing.T) {
+		var values struct {
+			Set     Optional[int] `json:"set"`
+			Null    Optional[int] `json:"null"`
+			Missing Optional[int] `json:"missing"`
+		}
+
+		err := json.Unmarshal([]byte(`{"set": 2005, "null": null}`), &values)
+
+		assert.Nil(t, err)
+		assert.Equal(t, Some(2005), values.Set)
+		assert.False(t, values.Null.IsSet())
+		assert.False(t, values.Missing.IsSet())
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		var value Optional[int]
+
+		err := json.Unmarshal([]byte(`"2005"`), &value)
+
+		assert.EqualError(t, err, "json: cannot unmarshal string into Go value of type int")
+		assert.False(t, value.IsSet())
+	})
+
+	t.Run("encodes values and nulls", func(t *testing.T) {
+		b, err := json.Marshal([]Optional[int]{Some(2005), {}})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "[2005,null]", string(b))
+	})
+
+	t.Run("gets values", func(t *testing.T) {
+		value, ok := Some(0).Get()
+		assert.Equal(t, 0, value)
+		assert.True(t, ok)
+
+		value, ok = Optional[int]{}.Get()
+		assert.Equal(t, 0, value)
+		assert.False(t, ok)
+
+		assert.Equal(t, 2005, Some(2005).OrElse(1))
+		assert.Equal(t, 1, Optional[int]{}.OrElse(1))
+	})
+}
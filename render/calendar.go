@@ -0,0 +1,320 @@
+package render
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// Escapes text in Markdown table cells, where pipes end the cell and tags would be rendered as HTML
+var markdownCellEscape = strings.NewReplacer("|", `\|`, "[", `\[`, "]", `\]`, "<", "&lt;", ">", "&gt;", "\n", " ")
+
+// A month of Events laid out as a grid of weeks
+type Calendar struct {
+	Year     int            // The year
+	Month    time.Month     // The month
+	Weekdays []time.Weekday // The column headings, starting on the first day of the week
+	Weeks    []CalendarWeek // The weeks, including days of the previous and next months that fill the first and last weeks
+}
+
+// A week of a Calendar
+type CalendarWeek struct {
+	Days  []CalendarDay       // The 7 days of the week
+	Lanes [][]CalendarSegment // Rows of multi-day Events; the segments of each lane cover all 7 days
+}
+
+// A day of a Calendar
+type CalendarDay struct {
+	Date    time.Time               // The date, at midnight UTC
+	InMonth bool                    // Whether the day is in the Calendar's month
+	Events  []holidays.EventSummary // The day's single-day Events
+}
+
+// The part of a multi-day Event that falls within a week, or an empty gap between them
+type CalendarSegment struct {
+	Event           *holidays.EventSummary // The Event, nil for a gap
+	Columns         int                    // How many days the segment covers
+	Start           time.Time              // The first day of the whole Event, or the Calendar's first day if the Event StartedBefore it
+	End             time.Time              // The last day of the whole Event within the Calendar's days
+	ContinuesBefore bool                   // Whether the Event started before this segment
+	ContinuesAfter  bool                   // Whether the Event continues after this segment
+	StartedBefore   bool                   // Whether the Event was already ongoing on the Calendar's first day, so its real start is unknown
+}
+
+// Gets the day of the month
+func (d CalendarDay) Day() int {
+	return d.Date.Day()
+}
+
+// Gets the Calendar's title, e.g. "May 2025"
+func (c Calendar) Title() string {
+	return c.Month.String() + " " + strconv.Itoa(c.Year)
+}
+
+// The default Calendar HTML template. Override it by passing another template to Calendar.HTML.
+var DefaultCalendarTemplate = template.Must(template.New("calendar").Parse(
+	`<table class="calendar">
+<caption>{{.Title}}</caption>
+<thead>
+<tr>{{range .Weekdays}}<th scope="col">{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Weeks}}<tr class="dates">{{range .Days}}<td{{if not .InMonth}} class="other-month"{{end}}>{{if .InMonth}}{{.Day}}{{end}}</td>{{end}}</tr>
+{{range .Lanes}}<tr class="multiday">{{range .}}{{if .Event}}<td colspan="{{.Columns}}" class="multiday{{if .ContinuesBefore}} continues-before{{end}}{{if .ContinuesAfter}} continues-after{{end}}"><a href="{{.Event.Url}}">{{.Event.Name}}</a></td>{{else}}<td colspan="{{.Columns}}"></td>{{end}}{{end}}</tr>
+{{end}}<tr class="events">{{range .Days}}<td>{{if .Events}}<ul>{{range .Events}}<li><a href="{{.Url}}">{{.Name}}</a></li>{{end}}</ul>{{end}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+`))
+
+// A multi-day Event's days within the month
+type calendarSpan struct {
+	event         holidays.EventSummary
+	start, end    time.Time
+	startedBefore bool
+}
+
+// Lays out a month of GetEvents responses, one per day, as a Calendar with weeks starting on weekStart.
+// Multi-day Events span from the day they start through the consecutive days they are ongoing.
+func NewCalendar(days []*holidays.GetEventsResponse, weekStart time.Weekday) (Calendar, error) {
+	if len(days) == 0 {
+		return Calendar{}, errors.New("no days to render")
+	}
+
+	byDate := make(map[time.Time]*holidays.GetEventsResponse, len(days))
+	var dates []time.Time
+	for _, res := range days {
+		date, err := time.Parse(holidays.DateLayout, res.Date)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("can't parse date %q: %w", res.Date, err)
+		}
+		if len(dates) > 0 && (date.Year() != dates[0].Year() || date.Month() != dates[0].Month()) {
+			return Calendar{}, errors.New("can't render days from different months")
+		}
+		byDate[date] = res
+		dates = append(dates, date)
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	first := time.Date(dates[0].Year(), dates[0].Month(), 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{Year: first.Year(), Month: first.Month()}
+	for i := 0; i < 7; i++ {
+		cal.Weekdays = append(cal.Weekdays, (weekStart+time.Weekday(i))%7)
+	}
+
+	spans := calendarSpans(dates, byDate)
+
+	gridStart := first.AddDate(0, 0, -int((first.Weekday()-weekStart+7)%7))
+	for start := gridStart; start.Month() == cal.Month || start.Before(first); start = start.AddDate(0, 0, 7) {
+		var week CalendarWeek
+		for i := 0; i < 7; i++ {
+			date := start.AddDate(0, 0, i)
+			day := CalendarDay{Date: date, InMonth: date.Month() == cal.Month}
+			if res, ok := byDate[date]; ok {
+				day.Events = res.Events
+			}
+			week.Days = append(week.Days, day)
+		}
+		week.Lanes = calendarLanes(spans, start)
+		cal.Weeks = append(cal.Weeks, week)
+	}
+
+	return cal, nil
+}
+
+// Gets the multi-day Events of each day, from the day they start (or the first day, if they started earlier)
+// through the following days that list them as ongoing
+func calendarSpans(dates []time.Time, byDate map[time.Time]*holidays.GetEventsResponse) []calendarSpan {
+	ongoing := func(date time.Time, id string) bool {
+		res, ok := byDate[date]
+		if !ok {
+			return false
+		}
+		for _, event := range res.MultidayOngoing {
+			if event.Id == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	var spans []calendarSpan
+	add := func(event holidays.EventSummary, start time.Time, startedBefore bool) {
+		end := start
+		for ongoing(end.AddDate(0, 0, 1), event.Id) {
+			end = end.AddDate(0, 0, 1)
+		}
+		spans = append(spans, calendarSpan{event: event, start: start, end: end, startedBefore: startedBefore})
+	}
+
+	for _, event := range byDate[dates[0]].MultidayOngoing {
+		add(event, dates[0], true)
+	}
+	for _, date := range dates {
+		for _, event := range byDate[date].MultidayStarting {
+			add(event, date, false)
+		}
+	}
+
+	return spans
+}
+
+// Arranges the parts of spans within the week starting on weekStart into lanes that don't overlap
+func calendarLanes(spans []calendarSpan, weekStart time.Time) [][]CalendarSegment {
+	weekEnd := weekStart.AddDate(0, 0, 6)
+
+	type placed struct {
+		span     calendarSpan
+		from, to int // the first and last columns
+		lane     int
+	}
+	var parts []placed
+	for _, span := range spans {
+		if span.end.Before(weekStart) || span.start.After(weekEnd) {
+			continue
+		}
+		from, to := 0, 6
+		if span.start.After(weekStart) {
+			from = int(span.start.Sub(weekStart).Hours() / 24)
+		}
+		if span.end.Before(weekEnd) {
+			to = int(span.end.Sub(weekStart).Hours() / 24)
+		}
+		parts = append(parts, placed{span: span, from: from, to: to})
+	}
+
+	// place earlier and then longer parts first, in the first lane with room
+	sort.SliceStable(parts, func(i, j int) bool {
+		if parts[i].from != parts[j].from {
+			return parts[i].from < parts[j].from
+		}
+		return parts[i].to > parts[j].to
+	})
+	var laneEnds []int
+	for i := range parts {
+		lane := 0
+		for lane < len(laneEnds) && laneEnds[lane] >= parts[i].from {
+			lane++
+		}
+		if lane == len(laneEnds) {
+			laneEnds = append(laneEnds, -1)
+		}
+		laneEnds[lane] = parts[i].to
+		parts[i].lane = lane
+	}
+
+	lanes := make([][]CalendarSegment, len(laneEnds))
+	column := make([]int, len(laneEnds))
+	for _, part := range parts {
+		if gap := part.from - column[part.lane]; gap > 0 {
+			lanes[part.lane] = append(lanes[part.lane], CalendarSegment{Columns: gap})
+		}
+		event := part.span.event
+		lanes[part.lane] = append(lanes[part.lane], CalendarSegment{
+			Event:           &event,
+			Columns:         part.to - part.from + 1,
+			Start:           part.span.start,
+			End:             part.span.end,
+			ContinuesBefore: part.span.startedBefore || part.span.start.Before(weekStart),
+			ContinuesAfter:  part.span.end.After(weekEnd),
+			StartedBefore:   part.span.startedBefore,
+		})
+		column[part.lane] = part.to + 1
+	}
+	for lane := range lanes {
+		if gap := 7 - column[lane]; gap > 0 {
+			lanes[lane] = append(lanes[lane], CalendarSegment{Columns: gap})
+		}
+	}
+
+	return lanes
+}
+
+// Renders the Calendar as HTML with tmpl, which defaults to DefaultCalendarTemplate
+func (c Calendar) HTML(w io.Writer, tmpl *template.Template) error {
+	if tmpl == nil {
+		tmpl = DefaultCalendarTemplate
+	}
+	if err := tmpl.Execute(w, c); err != nil {
+		return fmt.Errorf("can't render calendar: %w", err)
+	}
+	return nil
+}
+
+// Renders the Calendar as a Markdown table.
+// Markdown tables can't span cells, so multi-day Events are listed in the first cell of each week they cover,
+// with their dates. Events that started before the month only show when they end.
+func (c Calendar) Markdown() string {
+	var b strings.Builder
+	b.WriteString("## " + c.Title() + "\n\n|")
+	for _, weekday := range c.Weekdays {
+		b.WriteString(" " + weekday.String()[:3] + " |")
+	}
+	b.WriteString("\n|")
+	b.WriteString(strings.Repeat(" --- |", len(c.Weekdays)))
+	b.WriteString("\n")
+
+	for _, week := range c.Weeks {
+		cells := make([][]string, len(week.Days))
+		for i, day := range week.Days {
+			if day.InMonth {
+				cells[i] = append(cells[i], "**"+strconv.Itoa(day.Day())+"**")
+			}
+		}
+		for _, lane := range week.Lanes {
+			column := 0
+			for _, segment := range lane {
+				if segment.Event != nil {
+					when := dayRange(segment.Start, segment.End)
+					if segment.StartedBefore {
+						when = "through " + segment.End.Format("Jan 2")
+					}
+					line := markdownCellLink(*segment.Event) + " (" + when + ")"
+					if segment.ContinuesBefore {
+						line = "↪ " + line
+					}
+					cells[column] = append(cells[column], line)
+				}
+				column += segment.Columns
+			}
+		}
+		for i, day := range week.Days {
+			for _, event := range day.Events {
+				cells[i] = append(cells[i], markdownCellLink(event))
+			}
+		}
+
+		b.WriteString("|")
+		for _, cell := range cells {
+			b.WriteString(" " + strings.Join(cell, "<br>") + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+func markdownCellLink(event holidays.EventSummary) string {
+	name := markdownCellEscape.Replace(event.Name)
+	if event.Url == "" {
+		return name
+	}
+	return "[" + name + "](" + event.Url + ")"
+}
+
+// Formats a range of days, e.g. "May 5–11" or "Apr 28–May 4"
+func dayRange(start, end time.Time) string {
+	if start.Equal(end) {
+		return start.Format("Jan 2")
+	}
+	if start.Month() == end.Month() {
+		return start.Format("Jan 2") + "–" + end.Format("2")
+	}
+	return start.Format("Jan 2") + "–" + end.Format("Jan 2")
+}
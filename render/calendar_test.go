@@ -0,0 +1,174 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+func event(id, name string) holidays.EventSummary {
+	return holidays.EventSummary{Id: id, Name: name, Url: "https://www.checkiday.com/" + id + "/x"}
+}
+
+// Builds May 2025: an Event continuing from April, a week-long Event crossing a week boundary,
+// an overlapping multi-day Event, and a few single-day Events
+func may2025() []*holidays.GetEventsResponse {
+	kind := event("kind", "Be Kind to Animals Week")
+	teacher := event("teacher", "Teacher Appreciation Week")
+	pets := event("pets", "Pets | Friends [Weekend]")
+
+	var days []*holidays.GetEventsResponse
+	for day := 1; day <= 31; day++ {
+		res := &holidays.GetEventsResponse{Date: fmt.Sprintf("05/%02d/2025", day), Timezone: "America/Chicago"}
+		switch {
+		case day <= 3:
+			res.MultidayOngoing = append(res.MultidayOngoing, kind)
+		case day == 8:
+			res.MultidayStarting = append(res.MultidayStarting, teacher)
+		case day > 8 && day <= 14:
+			res.MultidayOngoing = append(res.MultidayOngoing, teacher)
+		}
+		if day == 9 {
+			res.MultidayStarting = append(res.MultidayStarting, pets)
+		}
+		if day == 10 || day == 11 {
+			res.MultidayOngoing = append(res.MultidayOngoing, pets)
+		}
+		if day == 5 {
+			res.Events = []holidays.EventSummary{event("cinco", "Cinco de Mayo"), event("lakes", "Great Lakes Awareness Day")}
+		}
+		if day == 31 {
+			res.Events = []holidays.EventSummary{event("smile", "<Smile> & Wave Day")}
+		}
+		days = append(days, res)
+	}
+	return days
+}
+
+func TestCalendar(t *testing.T) {
+	t.Run("lays out weeks", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Sunday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2025, cal.Year)
+		assert.Equal(t, time.May, cal.Month)
+		assert.Equal(t, "May 2025", cal.Title())
+		assert.Equal(t, time.Sunday, cal.Weekdays[0])
+		assert.Equal(t, 5, len(cal.Weeks))
+		assert.Equal(t, 27, cal.Weeks[0].Days[0].Day())
+		assert.False(t, cal.Weeks[0].Days[0].InMonth)
+		assert.Equal(t, 1, cal.Weeks[0].Days[4].Day())
+		assert.Equal(t, 31, cal.Weeks[4].Days[6].Day())
+		assert.Equal(t, 2, len(cal.Weeks[1].Days[1].Events))
+	})
+
+	t.Run("spans multi-day events", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Sunday)
+
+		assert.Nil(t, err)
+
+		first := cal.Weeks[0].Lanes
+		assert.Equal(t, 1, len(first))
+		assert.Equal(t, 4, first[0][0].Columns)
+		assert.Nil(t, first[0][0].Event)
+		assert.Equal(t, "Be Kind to Animals Week", first[0][1].Event.Name)
+		assert.Equal(t, 3, first[0][1].Columns)
+		assert.True(t, first[0][1].ContinuesBefore)
+		assert.True(t, first[0][1].StartedBefore)
+
+		second := cal.Weeks[1].Lanes
+		assert.Equal(t, 2, len(second))
+		assert.Equal(t, "Teacher Appreciation Week", second[0][1].Event.Name)
+		assert.Equal(t, 3, second[0][1].Columns)
+		assert.True(t, second[0][1].ContinuesAfter)
+		assert.Equal(t, "Pets | Friends [Weekend]", second[1][1].Event.Name)
+		assert.Equal(t, 2, second[1][1].Columns)
+		assert.True(t, second[1][1].ContinuesAfter)
+
+		third := cal.Weeks[2].Lanes
+		assert.Equal(t, 2, len(third))
+		assert.Equal(t, "Pets | Friends [Weekend]", third[1][0].Event.Name)
+		assert.Equal(t, 1, third[1][0].Columns)
+		assert.True(t, third[1][0].ContinuesBefore)
+		assert.Equal(t, 4, third[0][0].Columns)
+		assert.True(t, third[0][0].ContinuesBefore)
+		assert.False(t, third[0][0].StartedBefore)
+		assert.False(t, third[0][0].ContinuesAfter)
+		assert.Equal(t, time.Date(2025, time.May, 8, 0, 0, 0, 0, time.UTC), third[0][0].Start)
+		assert.Equal(t, time.Date(2025, time.May, 14, 0, 0, 0, 0, time.UTC), third[0][0].End)
+
+		assert.Equal(t, 0, len(cal.Weeks[3].Lanes))
+	})
+
+	t.Run("starts weeks on any day", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Monday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}, cal.Weekdays)
+		assert.Equal(t, 28, cal.Weeks[0].Days[0].Day())
+		assert.Equal(t, 5, len(cal.Weeks))
+		assert.Equal(t, 1, cal.Weeks[4].Days[6].Day())
+	})
+
+	t.Run("renders HTML", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Sunday)
+		assert.Nil(t, err)
+		var b bytes.Buffer
+
+		assert.Nil(t, cal.HTML(&b, nil))
+
+		testutil.AssertGolden(t, "calendar.golden.html", b.Bytes())
+	})
+
+	t.Run("renders HTML with a custom template", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Sunday)
+		assert.Nil(t, err)
+		tmpl := template.Must(template.New("custom").Parse(`<h1>{{.Title}}</h1>{{len .Weeks}} weeks`))
+		var b bytes.Buffer
+
+		assert.Nil(t, cal.HTML(&b, tmpl))
+
+		assert.Equal(t, "<h1>May 2025</h1>5 weeks", b.String())
+	})
+
+	t.Run("renders Markdown", func(t *testing.T) {
+		cal, err := NewCalendar(may2025(), time.Sunday)
+		assert.Nil(t, err)
+
+		testutil.AssertGolden(t, "calendar.golden.md", []byte(cal.Markdown()))
+	})
+
+	t.Run("uses fixtures", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json")
+
+		cal, err := NewCalendar([]*holidays.GetEventsResponse{res}, time.Sunday)
+
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(cal.Weeks[1].Days[1].Events))
+		assert.Equal(t, 3, len(cal.Weeks[1].Lanes))
+	})
+
+	t.Run("fails without days", func(t *testing.T) {
+		_, err := NewCalendar(nil, time.Sunday)
+
+		assert.EqualError(t, err, "no days to render")
+	})
+
+	t.Run("fails on mixed months", func(t *testing.T) {
+		_, err := NewCalendar([]*holidays.GetEventsResponse{{Date: "05/31/2025"}, {Date: "06/01/2025"}}, time.Sunday)
+
+		assert.EqualError(t, err, "can't render days from different months")
+	})
+
+	t.Run("fails on invalid dates", func(t *testing.T) {
+		_, err := NewCalendar([]*holidays.GetEventsResponse{{Date: "2025-05-31"}}, time.Sunday)
+
+		assert.ErrorContains(t, err, "can't parse date \"2025-05-31\"")
+	})
+}
@@ -0,0 +1,151 @@
+package render
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// Discord embed limits, see https://discord.com/developers/docs/resources/message#embed-object-embed-limits
+const (
+	discordTitleMax       = 256
+	discordDescriptionMax = 4096
+	discordFieldsMax      = 25
+	discordFieldNameMax   = 256
+	discordFieldValueMax  = 1024
+	discordFooterMax      = 2048
+	discordEmbedTotalMax  = 6000
+)
+
+var discordEscape = strings.NewReplacer("[", "\\[", "]", "\\]")
+
+// A Discord message made of embeds
+type DiscordMessage struct {
+	Embeds []DiscordEmbed `json:"embeds"`
+}
+
+// A Discord embed
+type DiscordEmbed struct {
+	Title       string         `json:"title,omitempty"`
+	Url         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Image       *DiscordImage  `json:"image,omitempty"`
+	Fields      []DiscordField `json:"fields,omitempty"`
+	Footer      *DiscordFooter `json:"footer,omitempty"`
+}
+
+// A Discord embed image
+type DiscordImage struct {
+	Url string `json:"url"`
+}
+
+// A Discord embed field
+type DiscordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// A Discord embed footer
+type DiscordFooter struct {
+	Text string `json:"text"`
+}
+
+// Renders the Events of a GetEvents response as a Discord message
+func DiscordEvents(res *holidays.GetEventsResponse) DiscordMessage {
+	embed := DiscordEmbed{
+		Title:       truncate("Holidays for "+res.Date, discordTitleMax),
+		Description: joinLines(discordList(res.Events), discordDescriptionMax),
+	}
+
+	fields := []struct {
+		name   string
+		events []holidays.EventSummary
+	}{
+		{"Multi-day events starting", res.MultidayStarting},
+		{"Multi-day events ongoing", res.MultidayOngoing},
+	}
+	for _, field := range fields {
+		if len(field.events) == 0 {
+			continue
+		}
+		embed.Fields = append(embed.Fields, DiscordField{
+			Name:  field.name,
+			Value: joinLines(discordList(field.events), discordFieldValueMax),
+		})
+	}
+
+	return DiscordMessage{Embeds: []DiscordEmbed{fitDiscordEmbed(embed)}}
+}
+
+// Renders an Event's info as a Discord message
+func DiscordEventInfo(event holidays.EventInfo) DiscordMessage {
+	embed := DiscordEmbed{
+		Title:       truncate(event.Name, discordTitleMax),
+		Url:         event.Url,
+		Description: truncate(normalize(event.Description.Markdown), discordDescriptionMax),
+	}
+
+	if event.Image.Medium != "" {
+		embed.Image = &DiscordImage{Url: event.Image.Medium}
+	}
+
+	if howToObserve := normalize(event.HowToObserve.Markdown); howToObserve != "" {
+		embed.Fields = append(embed.Fields, DiscordField{
+			Name:  "How to observe",
+			Value: truncate(howToObserve, discordFieldValueMax),
+		})
+	}
+
+	if len(event.Hashtags) > 0 {
+		embed.Footer = &DiscordFooter{Text: truncate(hashtags(event.Hashtags), discordFooterMax)}
+	}
+
+	return DiscordMessage{Embeds: []DiscordEmbed{fitDiscordEmbed(embed)}}
+}
+
+// Formats Events as a Markdown list of links
+func discordList(events []holidays.EventSummary) []string {
+	lines := make([]string, len(events))
+	for i, event := range events {
+		name := discordEscape.Replace(event.Name)
+		if event.Url == "" {
+			lines[i] = "- " + name
+		} else {
+			lines[i] = "- [" + name + "](" + event.Url + ")"
+		}
+	}
+	return lines
+}
+
+// Drops fields and shortens the description until the embed fits Discord's total size limit
+func fitDiscordEmbed(embed DiscordEmbed) DiscordEmbed {
+	if len(embed.Fields) > discordFieldsMax {
+		embed.Fields = embed.Fields[:discordFieldsMax]
+	}
+	for i := range embed.Fields {
+		embed.Fields[i].Name = truncate(embed.Fields[i].Name, discordFieldNameMax)
+	}
+
+	for discordEmbedSize(embed) > discordEmbedTotalMax && len(embed.Fields) > 0 {
+		embed.Fields = embed.Fields[:len(embed.Fields)-1]
+	}
+	if over := discordEmbedSize(embed) - discordEmbedTotalMax; over > 0 {
+		embed.Description = truncate(embed.Description, utf8.RuneCountInString(embed.Description)-over)
+	}
+
+	return embed
+}
+
+// Counts the characters Discord includes in its total embed size
+func discordEmbedSize(embed DiscordEmbed) int {
+	size := utf8.RuneCountInString(embed.Title) + utf8.RuneCountInString(embed.Description)
+	for _, field := range embed.Fields {
+		size += utf8.RuneCountInString(field.Name) + utf8.RuneCountInString(field.Value)
+	}
+	if embed.Footer != nil {
+		size += utf8.RuneCountInString(embed.Footer.Text)
+	}
+	return size
+}
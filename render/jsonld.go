@@ -0,0 +1,88 @@
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// A schema.org Event, see https://schema.org/Event
+type JSONLDEvent struct {
+	Context     string            `json:"@context"`
+	Type        string            `json:"@type"`
+	Name        string            `json:"name"`
+	StartDate   string            `json:"startDate"`
+	EndDate     string            `json:"endDate"`
+	Description string            `json:"description,omitempty"`
+	Image       []string          `json:"image,omitempty"`
+	Url         string            `json:"url,omitempty"`
+	SameAs      []string          `json:"sameAs,omitempty"`
+	Organizer   []JSONLDOrganizer `json:"organizer,omitempty"`
+}
+
+// A schema.org Organization that organizes an Event
+type JSONLDOrganizer struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	Url  string `json:"url,omitempty"`
+}
+
+// Renders an Event's Occurrence as schema.org Event structured data.
+// The name is the one the Event had in the Occurrence's year, and the end date is derived from the Occurrence's length.
+func JSONLD(event holidays.EventInfo, occurrence holidays.Occurrence) (JSONLDEvent, error) {
+	start, err := occurrence.Time()
+	if err != nil {
+		return JSONLDEvent{}, err
+	}
+
+	length := occurrence.Length
+	if length < 1 {
+		length = 1
+	}
+	end := start.AddDate(0, 0, length-1)
+
+	layout := time.DateOnly
+	if start.Hour() != 0 || start.Minute() != 0 || start.Second() != 0 || start.Location() != time.UTC {
+		layout = time.RFC3339
+	}
+
+	ld := JSONLDEvent{
+		Context:     "https://schema.org",
+		Type:        "Event",
+		Name:        event.NameOn(start),
+		StartDate:   start.Format(layout),
+		EndDate:     end.Format(layout),
+		Description: normalize(event.Description.Text),
+		Url:         event.Url,
+		SameAs:      event.Sources,
+	}
+
+	for _, image := range []string{event.Image.Large, event.Image.Medium, event.Image.Small} {
+		if image != "" {
+			ld.Image = append(ld.Image, image)
+		}
+	}
+
+	// the API doesn't say whether a founder is a person, so they are all described as organizations
+	for _, founder := range event.Founders {
+		ld.Organizer = append(ld.Organizer, JSONLDOrganizer{
+			Type: "Organization",
+			Name: founder.Name,
+			Url:  founder.Url,
+		})
+	}
+
+	return ld, nil
+}
+
+// Renders the structured data as a <script type="application/ld+json"> element for embedding in a page
+func (e JSONLDEvent) Script() (string, error) {
+	// json.Marshal escapes <, > and &, so the data can't close the script element early
+	b, err := json.Marshal(e)
+	if err != nil {
+		return "", fmt.Errorf("can't encode JSON-LD: %w", err)
+	}
+	return `<script type="application/ld+json">` + string(b) + `</script>`, nil
+}
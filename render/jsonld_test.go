@@ -0,0 +1,84 @@
+package render
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+func TestJSONLD(t *testing.T) {
+	t.Run("renders an occurrence", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json")
+
+		ld, err := JSONLD(res.Event, res.Event.Occurrences[4])
+		assert.Nil(t, err)
+		b, _ := json.Marshal(ld)
+
+		assert.JSONEq(t, `{
+			"@context": "https://schema.org",
+			"@type": "Event",
+			"name": "International Cat Day",
+			"startDate": "2024-08-08",
+			"endDate": "2024-08-08",
+			"description": "International Cat Day celebrates love for cats, and also focuses on the importance of keeping them safe, as well as on protecting more vulnerable wildlife that they come into contact with. The day was created by the International Fund for Animal Welfare.",
+			"image": [
+				"https://static.checkiday.com/img/1200/kittens-555822.jpg",
+				"https://static.checkiday.com/img/600/kittens-555822.jpg",
+				"https://static.checkiday.com/img/300/kittens-555822.jpg"
+			],
+			"url": "https://www.checkiday.com/f90b893ea04939d7456f30c54f68d7b4/international-cat-day",
+			"sameAs": [
+				"https://www.ibtimes.com/international-cat-day-2014-cat-lovers-worldwide-celebrate-feline-obsession-1653614",
+				"https://www.ifaw.org/united-states/news/ifaw-marks-international-cat-day"
+			],
+			"organizer": [
+				{"@type": "Organization", "name": "International Fund For Animal Welfare", "url": "https://www.ifaw.org/"}
+			]
+		}`, string(b))
+	})
+
+	t.Run("spans multi-day occurrences", func(t *testing.T) {
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Name: "Teacher Appreciation Week"},
+			AlternateNames: []holidays.AlternateName{
+				{Name: "Teachers Week", LastYear: holidays.Some(2000)},
+			},
+		}
+
+		ld, err := JSONLD(event, holidays.Occurrence{Date: "12/29/1999", Length: 7})
+		assert.Nil(t, err)
+		b, _ := json.Marshal(ld)
+
+		assert.JSONEq(t, `{
+			"@context": "https://schema.org",
+			"@type": "Event",
+			"name": "Teachers Week",
+			"startDate": "1999-12-29",
+			"endDate": "2000-01-04"
+		}`, string(b))
+	})
+
+	t.Run("keeps timestamps", func(t *testing.T) {
+		ld, err := JSONLD(holidays.EventInfo{}, holidays.Occurrence{Date: "2025-03-20T04:01:00-05:00", Length: 1})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "2025-03-20T04:01:00-05:00", ld.StartDate)
+		assert.Equal(t, "2025-03-20T04:01:00-05:00", ld.EndDate)
+	})
+
+	t.Run("fails on invalid occurrences", func(t *testing.T) {
+		_, err := JSONLD(holidays.EventInfo{}, holidays.Occurrence{Date: "someday"})
+
+		assert.EqualError(t, err, "can't parse occurrence date \"someday\"")
+	})
+
+	t.Run("renders a safe script element", func(t *testing.T) {
+		script, err := JSONLDEvent{Name: "</script><b>"}.Script()
+
+		assert.Nil(t, err)
+		assert.Equal(t, `<script type="application/ld+json">{"@context":"","@type":"","name":"\u003c/script\u003e\u003cb\u003e","startDate":"","endDate":""}</script>`, script)
+	})
+}
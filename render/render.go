@@ -0,0 +1,42 @@
+// Package render turns API responses into formats for other platforms.
+package render
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const ellipsis = "…"
+
+// Shortens s to at most max characters (runes), ending it with an ellipsis if it was shortened
+func truncate(s string, max int) string {
+	if utf8.RuneCountInString(s) <= max {
+		return s
+	}
+	if max <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	return strings.TrimRight(string(runes[:max-1]), " \n") + ellipsis
+}
+
+// Joins lines with newlines, dropping trailing lines that don't fit within max characters
+// and noting how many were dropped
+func joinLines(lines []string, max int) string {
+	for n := len(lines); n > 0; n-- {
+		s := strings.Join(lines[:n], "\n")
+		if n < len(lines) {
+			s += fmt.Sprintf("\n…and %d more", len(lines)-n)
+		}
+		if utf8.RuneCountInString(s) <= max {
+			return s
+		}
+	}
+	return truncate(strings.Join(lines, "\n"), max)
+}
+
+// Normalizes line endings to \n and trims surrounding whitespace
+func normalize(s string) string {
+	return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+}
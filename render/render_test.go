@@ -0,0 +1,98 @@
+package render
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	holidays "github.com/westy92/holiday-event-api-go"
+	"github.com/westy92/holiday-event-api-go/internal/testutil"
+)
+
+func TestTruncate(t *testing.T) {
+	t.Run("keeps short text", func(t *testing.T) {
+		assert.Equal(t, "héllo", truncate("héllo", 5))
+	})
+
+	t.Run("shortens by characters, not bytes", func(t *testing.T) {
+		assert.Equal(t, "hé…", truncate("héllo", 3))
+		assert.Equal(t, "日本…", truncate("日本語のテキスト", 3))
+	})
+
+	t.Run("joins lines that fit", func(t *testing.T) {
+		lines := []string{"- one", "- two", "- three"}
+
+		assert.Equal(t, "- one\n- two\n- three", joinLines(lines, 100))
+		assert.Equal(t, "- one\n…and 2 more", joinLines(lines, 17))
+	})
+}
+
+func TestSlack(t *testing.T) {
+	t.Run("renders events", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json")
+
+		testutil.AssertGoldenJSON(t, "slack-events.golden.json", SlackEvents(res))
+	})
+
+	t.Run("renders event info", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json")
+
+		testutil.AssertGoldenJSON(t, "slack-event-info.golden.json", SlackEventInfo(res.Event))
+	})
+
+	t.Run("converts Markdown to mrkdwn", func(t *testing.T) {
+		assert.Equal(t, "*Bold* &amp; <https://example.com/?a=1&amp;b=2|a &lt;link&gt;>",
+			SlackMarkdown("**Bold** & [a <link>](https://example.com/?a=1&b=2)"))
+	})
+
+	t.Run("keeps parentheses in links", func(t *testing.T) {
+		assert.Equal(t, "See <https://en.wikipedia.org/wiki/Cat_(disambiguation)|Cat> now",
+			SlackMarkdown("See [Cat](https://en.wikipedia.org/wiki/Cat_(disambiguation)) now"))
+		assert.Equal(t, "<https://example.com/?a=1&amp;b=2|x>", SlackMarkdown(`[x](<https://example.com/?a=1&amp;b=2> "Title")`))
+	})
+
+	t.Run("converts italics", func(t *testing.T) {
+		assert.Equal(t, "_italic_, *bold*, *also bold* and _kept_ with 2 * 3 * 4",
+			SlackMarkdown("*italic*, **bold**, __also bold__ and _kept_ with 2 * 3 * 4"))
+	})
+
+	t.Run("respects length limits", func(t *testing.T) {
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Name: strings.Repeat("a", 200)},
+			Description:  holidays.RichText{Markdown: strings.Repeat("b", 2990) + " [link](https://example.com)"},
+		}
+
+		msg := SlackEventInfo(event)
+
+		assert.Len(t, []rune(msg.Blocks[0].Text.Text), slackHeaderMax)
+		assert.Equal(t, strings.Repeat("b", 2990)+"…", msg.Blocks[1].Text.Text)
+	})
+}
+
+func TestDiscord(t *testing.T) {
+	t.Run("renders events", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventsResponse](t, "getEvents-default.json")
+
+		testutil.AssertGoldenJSON(t, "discord-events.golden.json", DiscordEvents(res))
+	})
+
+	t.Run("renders event info", func(t *testing.T) {
+		res := testutil.LoadFixture[holidays.GetEventInfoResponse](t, "getEventInfo.json")
+
+		testutil.AssertGoldenJSON(t, "discord-event-info.golden.json", DiscordEventInfo(res.Event))
+	})
+
+	t.Run("respects length limits", func(t *testing.T) {
+		event := holidays.EventInfo{
+			EventSummary: holidays.EventSummary{Name: "Long"},
+			Description:  holidays.RichText{Markdown: strings.Repeat("a", 5000)},
+			HowToObserve: holidays.RichText{Markdown: strings.Repeat("b", 5000)},
+		}
+
+		embed := DiscordEventInfo(event).Embeds[0]
+
+		assert.Len(t, []rune(embed.Description), discordDescriptionMax)
+		assert.Len(t, []rune(embed.Fields[0].Value), discordFieldValueMax)
+		assert.LessOrEqual(t, discordEmbedSize(embed), discordEmbedTotalMax)
+	})
+}
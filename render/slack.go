@@ -0,0 +1,191 @@
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	holidays "github.com/westy92/holiday-event-api-go"
+)
+
+// Slack Block Kit limits, see https://api.slack.com/reference/block-kit/blocks
+const (
+	slackHeaderMax  = 150
+	slackSectionMax = 3000
+	slackContextMax = 2000
+	slackBlocksMax  = 50
+)
+
+var (
+	markdownBold    = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	markdownItalic  = regexp.MustCompile(`\*(\S(?:[^*]*\S)?)\*`)
+	slackEscapeText = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// A Slack message made of Block Kit blocks
+type SlackMessage struct {
+	Text   string       `json:"text"` // Fallback text for notifications
+	Blocks []SlackBlock `json:"blocks"`
+}
+
+// A Slack Block Kit block
+type SlackBlock struct {
+	Type      string      `json:"type"`
+	Text      *SlackText  `json:"text,omitempty"`
+	Accessory *SlackImage `json:"accessory,omitempty"`
+	Elements  []SlackText `json:"elements,omitempty"`
+}
+
+// A Slack Block Kit text object
+type SlackText struct {
+	Type string `json:"type"` // "plain_text" or "mrkdwn"
+	Text string `json:"text"`
+}
+
+// A Slack Block Kit image element
+type SlackImage struct {
+	Type     string `json:"type"` // Always "image"
+	ImageUrl string `json:"image_url"`
+	AltText  string `json:"alt_text"`
+}
+
+// Renders the Events of a GetEvents response as a Slack message
+func SlackEvents(res *holidays.GetEventsResponse) SlackMessage {
+	title := "Holidays for " + res.Date
+	msg := SlackMessage{
+		Text: title,
+		Blocks: []SlackBlock{
+			slackHeader(title),
+		},
+	}
+
+	sections := []struct {
+		heading string
+		events  []holidays.EventSummary
+	}{
+		{"", res.Events},
+		{"Multi-day events starting", res.MultidayStarting},
+		{"Multi-day events ongoing", res.MultidayOngoing},
+	}
+	for _, section := range sections {
+		if len(section.events) == 0 {
+			continue
+		}
+		var lines []string
+		if section.heading != "" {
+			lines = append(lines, "*"+section.heading+"*")
+		}
+		for _, event := range section.events {
+			lines = append(lines, "• "+slackLink(event.Url, event.Name))
+		}
+		msg.Blocks = append(msg.Blocks, slackSection(joinLines(lines, slackSectionMax)))
+	}
+
+	return msg
+}
+
+// Renders an Event's info as a Slack message
+func SlackEventInfo(event holidays.EventInfo) SlackMessage {
+	msg := SlackMessage{
+		Text: event.Name,
+		Blocks: []SlackBlock{
+			slackHeader(event.Name),
+		},
+	}
+
+	if description := normalize(event.Description.Markdown); description != "" {
+		block := slackSection(truncateMrkdwn(SlackMarkdown(description), slackSectionMax))
+		if event.Image.Medium != "" {
+			block.Accessory = &SlackImage{Type: "image", ImageUrl: event.Image.Medium, AltText: event.Name}
+		}
+		msg.Blocks = append(msg.Blocks, block)
+	}
+
+	if howToObserve := normalize(event.HowToObserve.Markdown); howToObserve != "" {
+		msg.Blocks = append(msg.Blocks, slackSection(truncateMrkdwn("*How to observe*\n"+SlackMarkdown(howToObserve), slackSectionMax)))
+	}
+
+	var footer []string
+	if len(event.Hashtags) > 0 {
+		footer = append(footer, slackEscapeText.Replace(hashtags(event.Hashtags)))
+	}
+	if event.Url != "" {
+		footer = append(footer, slackLink(event.Url, "More on Checkiday"))
+	}
+	if len(footer) > 0 {
+		msg.Blocks = append(msg.Blocks, SlackBlock{
+			Type:     "context",
+			Elements: []SlackText{{Type: "mrkdwn", Text: truncate(strings.Join(footer, " · "), slackContextMax)}},
+		})
+	}
+
+	if len(msg.Blocks) > slackBlocksMax {
+		msg.Blocks = msg.Blocks[:slackBlocksMax]
+	}
+
+	return msg
+}
+
+// Converts standard Markdown to Slack's mrkdwn dialect
+func SlackMarkdown(markdown string) string {
+	var b strings.Builder
+	last := 0
+	for _, link := range holidays.FindMarkdownLinks(markdown) {
+		b.WriteString(slackInline(markdown[last:link.Start]))
+		b.WriteString(slackLink(link.Url, link.Text))
+		last = link.End
+	}
+	b.WriteString(slackInline(markdown[last:]))
+	return b.String()
+}
+
+// Converts inline Markdown emphasis to mrkdwn and escapes control characters.
+// mrkdwn uses single asterisks for bold, so bold is set aside while *italic* becomes _italic_.
+func slackInline(s string) string {
+	s = markdownBold.ReplaceAllString(slackEscapeText.Replace(s), "\x00$1$2\x00")
+	s = markdownItalic.ReplaceAllString(s, "_${1}_")
+	return strings.ReplaceAll(s, "\x00", "*")
+}
+
+// Shortens mrkdwn like truncate, without leaving a link cut in half
+func truncateMrkdwn(mrkdwn string, max int) string {
+	short := truncate(mrkdwn, max)
+	if short == mrkdwn {
+		return short
+	}
+	if open := strings.LastIndex(short, "<"); open > strings.LastIndex(short, ">") {
+		short = strings.TrimRight(short[:open], " \n") + ellipsis
+	}
+	return short
+}
+
+// Formats a mrkdwn link
+func slackLink(url, text string) string {
+	if url == "" {
+		return slackEscapeText.Replace(text)
+	}
+	text = strings.NewReplacer("|", "¦").Replace(slackEscapeText.Replace(text))
+	return "<" + slackEscapeText.Replace(url) + "|" + text + ">"
+}
+
+func slackHeader(text string) SlackBlock {
+	return SlackBlock{
+		Type: "header",
+		Text: &SlackText{Type: "plain_text", Text: truncate(text, slackHeaderMax)},
+	}
+}
+
+func slackSection(mrkdwn string) SlackBlock {
+	return SlackBlock{
+		Type: "section",
+		Text: &SlackText{Type: "mrkdwn", Text: mrkdwn},
+	}
+}
+
+// Formats hashtags as "#One #Two"
+func hashtags(tags []string) string {
+	formatted := make([]string, len(tags))
+	for i, tag := range tags {
+		formatted[i] = "#" + strings.TrimPrefix(tag, "#")
+	}
+	return strings.Join(formatted, " ")
+}
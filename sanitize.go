@@ -0,0 +1,396 @@
+package holidays
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Options for sanitizing rich text
+type SanitizeOptions struct {
+	Target         string // The target attribute to set on links, e.g. "_blank". Optional, omitted if empty.
+	StripAffiliate bool   // Remove affiliate query parameters (such as Amazon's "tag") from links
+	AffiliateTag   string // Replace Amazon "tag" values with this affiliate tag instead of removing them. Optional.
+}
+
+// The tags kept by the sanitizer. Other tags are removed but their text is kept.
+var allowedTags = map[string]bool{
+	"a": true, "b": true, "blockquote": true, "br": true, "code": true, "em": true, "i": true,
+	"li": true, "ol": true, "p": true, "pre": true, "strong": true, "u": true, "ul": true,
+}
+
+// The tags removed along with their content
+var droppedTags = map[string]bool{
+	"iframe": true, "noscript": true, "object": true, "script": true, "style": true, "template": true, "textarea": true,
+}
+
+// Tags that never have content
+var voidTags = map[string]bool{
+	"br": true,
+}
+
+// The link schemes kept by the sanitizer. Relative links are kept as well.
+var allowedSchemes = map[string]bool{
+	"http": true, "https": true, "mailto": true,
+}
+
+// The query parameters Amazon uses to attribute affiliate referrals
+var affiliateParams = map[string]bool{
+	"tag": true, "ascsubtag": true, "linkcode": true, "linkid": true, "camp": true, "creative": true, "creativeasin": true,
+}
+
+// Matches Markdown link reference definitions: [label]: url "title"
+var markdownReferencePattern = regexp.MustCompile(`^( {0,3}\[[^\]]+\]:[ \t]*)(<[^<>\n]*>|\S+)(.*)$`)
+
+// Matches Markdown autolinks at the start of the text: <scheme:url> or <user@example.com>
+var markdownAutolinkPattern = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*|[^<>\s@]+@[^<>\s@]+)>`)
+
+// Matches an HTML entity reference at the start of the text, like &amp; or &#58;
+var entityPattern = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[a-zA-Z][a-zA-Z0-9]{1,31});`)
+
+// Escapes the characters of a decoded link that Markdown would otherwise read as syntax
+var markdownUrlEscaper = strings.NewReplacer(" ", "%20", "<", "%3C", ">", "%3E", `\`, "%5C")
+
+// Gets the HTML with only allow-listed tags, safe links and rel="nofollow noopener" on every link
+func (r RichText) SanitizedHtml(opts SanitizeOptions) string {
+	return SanitizeHtml(r.Html, opts)
+}
+
+// Gets the Markdown with unsafe links removed and affiliate parameters handled according to opts
+func (r RichText) SanitizedMarkdown(opts SanitizeOptions) string {
+	return SanitizeMarkdown(r.Markdown, opts)
+}
+
+// Gets ObservedHtml, sanitized like RichText.SanitizedHtml
+func (p Pattern) SanitizedObservedHtml(opts SanitizeOptions) string {
+	return SanitizeHtml(p.ObservedHtml, opts)
+}
+
+// Gets ObservedMarkdown, sanitized like RichText.SanitizedMarkdown
+func (p Pattern) SanitizedObservedMarkdown(opts SanitizeOptions) string {
+	return SanitizeMarkdown(p.ObservedMarkdown, opts)
+}
+
+// Sanitizes HTML for embedding in other pages.
+// Only allow-listed tags are kept, without attributes except link hrefs.
+// Links with unsafe schemes are unwrapped, and every kept link gets rel="nofollow noopener".
+// Unclosed tags are closed so the result is well-formed.
+func SanitizeHtml(s string, opts SanitizeOptions) string {
+	var b strings.Builder
+	var open []string
+	dropping := ""
+
+	for _, token := range tokenizeHtml(s) {
+		if dropping != "" {
+			if token.Type == htmlEndTag && token.Data == dropping {
+				dropping = ""
+			}
+			continue
+		}
+
+		switch token.Type {
+		case htmlText:
+			b.WriteString(htmlEscaper.Replace(token.Data))
+		case htmlStartTag:
+			if droppedTags[token.Data] {
+				if !token.SelfClosing {
+					dropping = token.Data
+				}
+				continue
+			}
+			if !allowedTags[token.Data] {
+				continue
+			}
+			if token.Data == "a" {
+				href, _ := token.attr("href")
+				href, ok := sanitizeUrl(href, opts)
+				if !ok {
+					continue
+				}
+				b.WriteString(`<a href="` + htmlEscaper.Replace(href) + `" rel="nofollow noopener"`)
+				if opts.Target != "" {
+					b.WriteString(` target="` + htmlEscaper.Replace(opts.Target) + `"`)
+				}
+				b.WriteString(">")
+			} else {
+				b.WriteString("<" + token.Data + ">")
+			}
+			if !voidTags[token.Data] && !token.SelfClosing {
+				open = append(open, token.Data)
+			}
+		case htmlEndTag:
+			// close the matching open tag, along with any tags left open inside it
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == token.Data {
+					for j := len(open) - 1; j >= i; j-- {
+						b.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+
+	return b.String()
+}
+
+// Sanitizes Markdown links: links with unsafe schemes are replaced by their text,
+// and affiliate parameters are handled according to opts.
+// Inline links, autolinks and reference definitions are checked after decoding their escapes and entities, and raw HTML tags are escaped.
+func SanitizeMarkdown(s string, opts SanitizeOptions) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if m := markdownReferencePattern.FindStringSubmatch(line); m != nil {
+			lines[i] = sanitizeMarkdownReference(m, opts)
+		} else {
+			lines[i] = sanitizeMarkdownInline(line, opts)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Sanitizes a link reference definition, dropping it if its link is unsafe
+func sanitizeMarkdownReference(m []string, opts SanitizeOptions) string {
+	dest := m[2]
+	bracketed := strings.HasPrefix(dest, "<")
+	if bracketed {
+		dest = dest[1 : len(dest)-1]
+	}
+	href, ok := sanitizeUrl(decodeMarkdownUrl(dest), opts)
+	if !ok {
+		return ""
+	}
+	href = encodeMarkdownUrl(href)
+	if bracketed {
+		href = "<" + href + ">"
+	}
+	return m[1] + href + m[3]
+}
+
+// Sanitizes the inline links, autolinks and raw HTML of a line
+func sanitizeMarkdownInline(s string, opts SanitizeOptions) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		switch s[i] {
+		case '[':
+			if text, dest, title, n, ok := markdownLink(s[i:]); ok {
+				text = sanitizeMarkdownInline(text, opts)
+				if href, ok := sanitizeUrl(decodeMarkdownUrl(dest), opts); ok {
+					b.WriteString("[" + text + "](" + encodeMarkdownUrl(href) + title + ")")
+				} else {
+					b.WriteString(text)
+				}
+				i += n
+				continue
+			}
+		case '<':
+			if m := markdownAutolinkPattern.FindStringSubmatch(s[i:]); m != nil {
+				if href, ok := sanitizeUrl(decodeMarkdownUrl(m[1]), opts); ok {
+					b.WriteString("<" + encodeMarkdownUrl(href) + ">")
+				} else {
+					b.WriteString(m[1])
+				}
+				i += len(m[0])
+				continue
+			}
+			// escape anything a renderer could take for an HTML tag, comment or declaration
+			if i+1 < len(s) && (isAsciiLetter(s[i+1]) || strings.IndexByte("/!?", s[i+1]) >= 0) {
+				b.WriteString("&lt;")
+				i++
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// Parses an inline link at the start of s: [text](url "title").
+// The url may contain balanced parentheses, like Wikipedia URLs do.
+// Returns the link's parts and length.
+func markdownLink(s string) (text, dest, title string, n int, ok bool) {
+	end := strings.IndexByte(s, ']')
+	if end < 0 || end+1 >= len(s) || s[end+1] != '(' {
+		return "", "", "", 0, false
+	}
+	text = s[1:end]
+
+	i := end + 2
+	if i < len(s) && s[i] == '<' {
+		close := strings.IndexByte(s[i:], '>')
+		if close < 0 {
+			return "", "", "", 0, false
+		}
+		dest = s[i+1 : i+close]
+		i += close + 1
+	} else {
+		depth := 0
+		start := i
+	dest:
+		for ; i < len(s); i++ {
+			switch c := s[i]; {
+			case c == '(':
+				depth++
+			case c == ')':
+				if depth == 0 {
+					break dest
+				}
+				depth--
+			case c == ' ' || c == '\t':
+				break dest
+			}
+		}
+		dest = s[start:i]
+	}
+
+	// an optional title, kept as is
+	if rest := strings.TrimLeft(s[i:], " \t"); len(rest) > 0 && rest[0] != ')' {
+		quote := rest[0]
+		if quote == '(' {
+			quote = ')'
+		} else if quote != '"' && quote != '\'' {
+			return "", "", "", 0, false
+		}
+		close := strings.IndexByte(rest[1:], quote)
+		if close < 0 {
+			return "", "", "", 0, false
+		}
+		titleEnd := len(s) - len(rest) + close + 2
+		title = " " + s[len(s)-len(rest):titleEnd]
+		i = titleEnd
+	}
+
+	i += len(s[i:]) - len(strings.TrimLeft(s[i:], " \t"))
+	if i >= len(s) || s[i] != ')' {
+		return "", "", "", 0, false
+	}
+	return text, dest, title, i + 1, true
+}
+
+// An inline Markdown link found by FindMarkdownLinks
+type MarkdownLink struct {
+	Start int    // The byte offset of the link's opening bracket
+	End   int    // The byte offset just past the link's closing parenthesis
+	Text  string // The link text, as written
+	Url   string // The link destination, with its escapes and entities decoded
+	Title string // The link title with its quotes and leading space, e.g. ` "Example"`, or "" if there is none
+}
+
+// Finds the inline links of Markdown, [text](url "title"), in order.
+// Link destinations may contain balanced parentheses, like Wikipedia URLs do.
+func FindMarkdownLinks(s string) []MarkdownLink {
+	var links []MarkdownLink
+	for i := 0; i < len(s); i++ {
+		if s[i] != '[' {
+			continue
+		}
+		if text, dest, title, n, ok := markdownLink(s[i:]); ok {
+			links = append(links, MarkdownLink{Start: i, End: i + n, Text: text, Url: decodeMarkdownUrl(dest), Title: title})
+			i += n - 1
+		}
+	}
+	return links
+}
+
+// Replaces each inline link in s with what replace returns for it
+func replaceMarkdownLinks(s string, replace func(link MarkdownLink) string) string {
+	var b strings.Builder
+	last := 0
+	for _, link := range FindMarkdownLinks(s) {
+		b.WriteString(s[last:link.Start])
+		b.WriteString(replace(link))
+		last = link.End
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// Decodes the backslash escapes and entity references of a Markdown link, as renderers do before following it
+func decodeMarkdownUrl(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s) && isAsciiPunct(s[i+1]):
+			i++
+		case s[i] == '&':
+			if entity := entityPattern.FindString(s[i:]); entity != "" {
+				b.WriteString(html.UnescapeString(entity))
+				i += len(entity) - 1
+				continue
+			}
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// Encodes a decoded link for Markdown, so renderers decode it back to the same link
+func encodeMarkdownUrl(href string) string {
+	href = markdownUrlEscaper.Replace(href)
+	if strings.Count(href, "(") != strings.Count(href, ")") {
+		href = strings.NewReplacer("(", "%28", ")", "%29").Replace(href)
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(href); i++ {
+		if href[i] == '&' && entityPattern.MatchString(href[i:]) {
+			b.WriteString("&amp;")
+		} else {
+			b.WriteByte(href[i])
+		}
+	}
+	return b.String()
+}
+
+// Reports whether c is ASCII punctuation, which Markdown lets a backslash escape
+func isAsciiPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// Checks that a link is safe to follow and handles its affiliate parameters
+func sanitizeUrl(href string, opts SanitizeOptions) (string, bool) {
+	href = strings.TrimSpace(href)
+	u, err := url.Parse(href)
+	if err != nil || href == "" {
+		return "", false
+	}
+	if u.Scheme != "" && !allowedSchemes[strings.ToLower(u.Scheme)] {
+		return "", false
+	}
+	if !isAmazonHost(u.Hostname()) || (!opts.StripAffiliate && opts.AffiliateTag == "") {
+		return href, true
+	}
+
+	// rewrite the raw query to keep the rest of the link byte-for-byte
+	var params []string
+	for _, param := range strings.Split(u.RawQuery, "&") {
+		key, _, _ := strings.Cut(param, "=")
+		if unescaped, err := url.QueryUnescape(key); err == nil {
+			key = unescaped
+		}
+		switch {
+		case opts.AffiliateTag != "" && key == "tag":
+			params = append(params, "tag="+url.QueryEscape(opts.AffiliateTag))
+		case opts.StripAffiliate && affiliateParams[strings.ToLower(key)]:
+		default:
+			params = append(params, param)
+		}
+	}
+	u.RawQuery = strings.Join(params, "&")
+	u.ForceQuery = false
+
+	return u.String(), true
+}
+
+// Reports whether host belongs to Amazon, whose links carry affiliate tags
+func isAmazonHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "amzn.to" || strings.HasPrefix(host, "amazon.") || strings.Contains(host, ".amazon.")
+}
@@ -0,0 +1,162 @@
+package holidays
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func loadEventInfo(t *testing.T) EventInfo {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/getEventInfo.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response GetEventInfoResponse
+	if err := json.Unmarshal(b, &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.Event
+}
+
+func TestSanitizeHtml(t *testing.T) {
+	t.Run("keeps allowed tags and adds rel", func(t *testing.T) {
+		pattern := loadEventInfo(t).Patterns[0]
+
+		assert.Equal(t, `annually on <a href="https://www.checkiday.com/8/8" rel="nofollow noopener">August 8th</a>`,
+			pattern.SanitizedObservedHtml(SanitizeOptions{}))
+	})
+
+	t.Run("sets the link target", func(t *testing.T) {
+		assert.Equal(t, `<p><a href="https://example.com" rel="nofollow noopener" target="_blank">x</a></p>`,
+			SanitizeHtml(`<p><a href="https://example.com" target="_self" onclick="steal()">x</a></p>`, SanitizeOptions{Target: "_blank"}))
+	})
+
+	t.Run("removes unsafe markup", func(t *testing.T) {
+		assert.Equal(t, `<p>Hi there <b>friend</b></p>`,
+			SanitizeHtml(`<p style="color:red">Hi <script>alert(1)</script>there <!-- hidden --><img src=x onerror=alert(1)><b>friend</b></p>`, SanitizeOptions{}))
+	})
+
+	t.Run("unwraps unsafe links", func(t *testing.T) {
+		assert.Equal(t, `click me`, SanitizeHtml(`<a href="javascript:alert(1)">click me</a>`, SanitizeOptions{}))
+		assert.Equal(t, `click me`, SanitizeHtml(`<a href=" JavaScript:alert(1)">click me</a>`, SanitizeOptions{}))
+		assert.Equal(t, `click me`, SanitizeHtml(`<a>click me</a>`, SanitizeOptions{}))
+	})
+
+	t.Run("escapes text and attributes", func(t *testing.T) {
+		assert.Equal(t, `<p>1 &lt; 2 &amp; &lt;b&gt;</p><a href="https://example.com/?a=1&amp;b=&quot;2&quot;" rel="nofollow noopener">&quot;q&quot;</a>`,
+			SanitizeHtml(`<p>1 < 2 &amp; &lt;b&gt;</p><a href='https://example.com/?a=1&amp;b="2"'>"q"</a>`, SanitizeOptions{}))
+	})
+
+	t.Run("balances tags", func(t *testing.T) {
+		assert.Equal(t, `<ul><li><em>one</em></li></ul>text`, SanitizeHtml(`<ul><li><em>one</ul></li>text</em>`, SanitizeOptions{}))
+		assert.Equal(t, `a<br>b`, SanitizeHtml(`a<br/>b</br>`, SanitizeOptions{}))
+	})
+
+	t.Run("strips affiliate parameters", func(t *testing.T) {
+		html := loadEventInfo(t).HowToObserve.SanitizedHtml(SanitizeOptions{StripAffiliate: true})
+
+		assert.Contains(t, html, `<a href="https://www.amazon.com/s?url=search-alias=aps&amp;field-keywords=birdbesafe+cat+collar&amp;sprefix=birdbesafe,aps,169&amp;crid=3685VO6WFTRUL" rel="nofollow noopener">collars</a>`)
+		assert.Contains(t, html, `<a href="https://www.amazon.com/s/?ref=nb_sb_noss_1?url=search-alias=aps&amp;field-keywords=catios&amp;rh=i:aps,k:catios" rel="nofollow noopener">catios</a>`)
+		assert.Contains(t, html, `<a href="https://secure.ifaw.org/united-states/secure/help-us-save-animals-and-places-they-call-home" rel="nofollow noopener">donate</a>`)
+		assert.NotContains(t, html, "checkiday08-20")
+	})
+
+	t.Run("rewrites affiliate tags", func(t *testing.T) {
+		assert.Equal(t, `<a href="https://www.amazon.com/s?k=cats&amp;tag=mine-20" rel="nofollow noopener">x</a>`,
+			SanitizeHtml(`<a href="https://www.amazon.com/s?k=cats&tag=checkiday08-20">x</a>`, SanitizeOptions{AffiliateTag: "mine-20"}))
+	})
+
+	t.Run("leaves other sites' parameters alone", func(t *testing.T) {
+		assert.Equal(t, `<a href="https://example.com/?tag=news" rel="nofollow noopener">x</a>`,
+			SanitizeHtml(`<a href="https://example.com/?tag=news">x</a>`, SanitizeOptions{StripAffiliate: true}))
+	})
+}
+
+func TestSanitizeMarkdown(t *testing.T) {
+	t.Run("strips affiliate parameters", func(t *testing.T) {
+		markdown := loadEventInfo(t).HowToObserve.SanitizedMarkdown(SanitizeOptions{StripAffiliate: true})
+
+		assert.Contains(t, markdown, "[collars](https://www.amazon.com/s?url=search-alias=aps&field-keywords=birdbesafe+cat+collar&sprefix=birdbesafe,aps,169&crid=3685VO6WFTRUL)")
+		assert.Contains(t, markdown, "[donate](https://secure.ifaw.org/united-states/secure/help-us-save-animals-and-places-they-call-home)")
+		assert.NotContains(t, markdown, "checkiday08-20")
+	})
+
+	t.Run("removes unsafe links", func(t *testing.T) {
+		assert.Equal(t, "click me or [this](https://example.com)",
+			SanitizeMarkdown("[click me](javascript:void) or [this](https://example.com)", SanitizeOptions{}))
+	})
+
+	t.Run("matches balanced parentheses in links", func(t *testing.T) {
+		assert.Equal(t, "x", SanitizeMarkdown("[x](javascript:alert(1))", SanitizeOptions{}))
+		assert.Equal(t, "[Cat](https://en.wikipedia.org/wiki/Cat_(disambiguation))",
+			SanitizeMarkdown("[Cat](https://en.wikipedia.org/wiki/Cat_(disambiguation))", SanitizeOptions{}))
+		assert.Equal(t, "[toys](https://www.amazon.com/Toy_(Cat)/dp/B01?th=1)",
+			SanitizeMarkdown("[toys](https://www.amazon.com/Toy_(Cat)/dp/B01?th=1&tag=checkiday08-20)", SanitizeOptions{StripAffiliate: true}))
+		assert.Equal(t, `[this](https://example.com "Example")`,
+			SanitizeMarkdown(`[this](https://example.com "Example")`, SanitizeOptions{}))
+	})
+
+	t.Run("removes unsafe autolinks", func(t *testing.T) {
+		assert.Equal(t, "javascript:alert(1)", SanitizeMarkdown("<javascript:alert(1)>", SanitizeOptions{}))
+		assert.Equal(t, "see <https://example.com> or <cats@example.com>",
+			SanitizeMarkdown("see <https://example.com> or <cats@example.com>", SanitizeOptions{}))
+	})
+
+	t.Run("removes unsafe reference definitions", func(t *testing.T) {
+		assert.Equal(t, "[x]\n", SanitizeMarkdown("[x]\n[x]: javascript:alert(1)", SanitizeOptions{}))
+		assert.Equal(t, "[x]\n", SanitizeMarkdown("[x]\n  [x]: <javascript:alert(1)>", SanitizeOptions{}))
+		assert.Equal(t, `[x]: https://www.amazon.com/dp/B01 "Toys"`,
+			SanitizeMarkdown(`[x]: https://www.amazon.com/dp/B01?tag=checkiday08-20 "Toys"`, SanitizeOptions{StripAffiliate: true}))
+	})
+
+	t.Run("decodes links before checking them", func(t *testing.T) {
+		assert.Equal(t, "x", SanitizeMarkdown("[x](javascript&#58;alert(1))", SanitizeOptions{}))
+		assert.Equal(t, "x", SanitizeMarkdown("[x](javascript&colon;alert(1))", SanitizeOptions{}))
+		assert.Equal(t, "x", SanitizeMarkdown("[x](&#x6A;avascript:alert(1))", SanitizeOptions{}))
+		assert.Equal(t, "x", SanitizeMarkdown(`[x](javascript\:alert(1))`, SanitizeOptions{}))
+		assert.Equal(t, "&lt;javascript&#58;alert(1)>", SanitizeMarkdown("<javascript&#58;alert(1)>", SanitizeOptions{}))
+		assert.Equal(t, "<https://www.amazon.com/dp/B01?th=1>",
+			SanitizeMarkdown("<https://www.amazon.com/dp/B01?th=1&amp;tag=checkiday08-20>", SanitizeOptions{StripAffiliate: true}))
+		assert.Equal(t, "[x]\n", SanitizeMarkdown("[x]\n[x]: javascript&#58;alert(1)", SanitizeOptions{}))
+		assert.Equal(t, "[x]\n", SanitizeMarkdown("[x]\n[x]: <javascript&colon;alert(1)>", SanitizeOptions{}))
+	})
+
+	t.Run("writes decoded links", func(t *testing.T) {
+		assert.Equal(t, "[x](https://example.com/a?b=1&c=2)", SanitizeMarkdown("[x](https&#58;//example.com/a?b=1&amp;c=2)", SanitizeOptions{}))
+		assert.Equal(t, "[x](https://example.com/a%20b%29)", SanitizeMarkdown(`[x](<https://example.com/a b\)>)`, SanitizeOptions{}))
+		assert.Equal(t, "[x](https://example.com/&amp;colon;)", SanitizeMarkdown("[x](https://example.com/&amp;colon;)", SanitizeOptions{}))
+		assert.Equal(t, "<https://example.com/?a=1&b=2>", SanitizeMarkdown("<https://example.com/?a=1&amp;b=2>", SanitizeOptions{}))
+		assert.Equal(t, "[x]: https://example.com/", SanitizeMarkdown("[x]: https&colon;//example.com/", SanitizeOptions{}))
+	})
+
+	t.Run("escapes raw HTML", func(t *testing.T) {
+		assert.Equal(t, `&lt;a href="javascript:alert(1)">x&lt;/a>`,
+			SanitizeMarkdown(`<a href="javascript:alert(1)">x</a>`, SanitizeOptions{}))
+		assert.Equal(t, "&lt;script>alert(1)&lt;/script>", SanitizeMarkdown("<script>alert(1)</script>", SanitizeOptions{}))
+		assert.Equal(t, "[&lt;img src=x>](https://example.com)", SanitizeMarkdown("[<img src=x>](https://example.com)", SanitizeOptions{}))
+		assert.Equal(t, "1 < 2", SanitizeMarkdown("1 < 2", SanitizeOptions{}))
+	})
+
+	t.Run("sanitizes observed markdown", func(t *testing.T) {
+		pattern := loadEventInfo(t).Patterns[0]
+
+		assert.Equal(t, "annually on [August 8th](https://www.checkiday.com/8/8)", pattern.SanitizedObservedMarkdown(SanitizeOptions{}))
+	})
+}
+
+func TestFindMarkdownLinks(t *testing.T) {
+	s := `See [Cat](https://en.wikipedia.org/wiki/Cat_(disambiguation)), [x] and [this](<https://example.com/?a=1&amp;b=2> "Example")`
+
+	links := FindMarkdownLinks(s)
+
+	assert.Equal(t, []MarkdownLink{
+		{Start: 4, End: 61, Text: "Cat", Url: "https://en.wikipedia.org/wiki/Cat_(disambiguation)"},
+		{Start: 71, End: len(s), Text: "this", Url: "https://example.com/?a=1&b=2", Title: ` "Example"`},
+	}, links)
+	assert.Equal(t, "[Cat]("+links[0].Url+")", s[links[0].Start:links[0].End])
+	assert.Empty(t, FindMarkdownLinks("no [links] here"))
+}
@@ -0,0 +1,234 @@
+package holidays
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const ellipsis = "…"
+
+// Abbreviations whose trailing period doesn't end a sentence
+var abbreviations = map[string]bool{
+	"dr": true, "e.g": true, "etc": true, "i.e": true, "jr": true, "mr": true, "mrs": true, "ms": true,
+	"no": true, "sr": true, "st": true, "u.s": true, "vs": true,
+}
+
+var (
+	markdownImage      = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	markdownStrong     = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	markdownEmphasis   = regexp.MustCompile(`\*(\S(?:[^*]*\S)?)\*`)
+	markdownUnderscore = regexp.MustCompile(`(^|[^\p{L}\p{N}_])_(\S(?:[^_]*\S)?)_($|[^\p{L}\p{N}_])`)
+	markdownCode       = regexp.MustCompile("`([^`]*)`")
+	markdownHeading    = regexp.MustCompile(`(?m)^ {0,3}#{1,6}\s+`)
+	markdownQuote      = regexp.MustCompile(`(?m)^ {0,3}>\s?`)
+	markdownBullet     = regexp.MustCompile(`^\s*[-*+]\s+`)
+	markdownNumbered   = regexp.MustCompile(`^\s*(\d{1,9})[.)]\s+`)
+	blankLines         = regexp.MustCompile(`\n\s*\n`)
+)
+
+// The characters Markdown lets authors escape with a backslash
+const markdownEscapable = "\\`*_{}[]()#+-.!>"
+
+// Gets a summary of the plain text of at most maxChars characters.
+// Whole sentences are kept when possible, otherwise the first sentence is cut at a word and ends with an ellipsis.
+func (r RichText) Summary(maxChars int) string {
+	return summarize(r.plain(), maxChars)
+}
+
+// Gets the first n sentences of the plain text
+func (r RichText) SummarySentences(n int) string {
+	sentences := splitSentences(r.plain())
+	if n < len(sentences) {
+		sentences = sentences[:n]
+	}
+	return strings.Join(sentences, " ")
+}
+
+// Gets the first paragraph of the plain text
+func (r RichText) FirstParagraph() string {
+	text := r.plain()
+	if i := strings.Index(text, "\n"); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
+// Gets the Markdown as plain text, keeping link text
+func (r RichText) PlainFromMarkdown() string {
+	return MarkdownToPlain(r.Markdown)
+}
+
+// Gets the plain text, falling back to the Markdown converted to plain text
+func (r RichText) plain() string {
+	text := r.Text
+	if strings.TrimSpace(text) == "" {
+		text = MarkdownToPlain(r.Markdown)
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n"))
+}
+
+// Removes list item markers. A number only counts as a marker when it starts a list at 1 or follows another item,
+// so a line like "1999. It was a good year." keeps its year.
+func stripListMarkers(s string) string {
+	lines := strings.Split(s, "\n")
+	inList := false
+	for i, line := range lines {
+		if m := markdownBullet.FindString(line); m != "" {
+			lines[i] = line[len(m):]
+			inList = true
+		} else if m := markdownNumbered.FindStringSubmatch(line); m != nil && (inList || m[1] == "1") {
+			lines[i] = line[len(m[0]):]
+			inList = true
+		} else if strings.TrimSpace(line) != "" {
+			inList = false
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Converts Markdown to plain text, replacing links and images with their text and removing formatting
+func MarkdownToPlain(markdown string) string {
+	s := strings.ReplaceAll(markdown, "\r\n", "\n")
+
+	// hide escaped characters from the formatting patterns, using private use characters
+	var escaped strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(markdownEscapable, s[i+1]) >= 0 {
+			escaped.WriteRune(rune(0xE000 + strings.IndexByte(markdownEscapable, s[i+1])))
+			i++
+			continue
+		}
+		escaped.WriteByte(s[i])
+	}
+	s = escaped.String()
+
+	s = markdownImage.ReplaceAllString(s, "$1")
+	s = replaceMarkdownLinks(s, func(link MarkdownLink) string {
+		return link.Text
+	})
+	s = markdownCode.ReplaceAllString(s, "$1")
+	s = markdownStrong.ReplaceAllString(s, "$1$2")
+	s = markdownEmphasis.ReplaceAllString(s, "$1")
+	s = markdownUnderscore.ReplaceAllString(s, "$1$2$3")
+	s = markdownHeading.ReplaceAllString(s, "")
+	s = markdownQuote.ReplaceAllString(s, "")
+	s = stripListMarkers(s)
+	s = strings.Map(func(r rune) rune {
+		if r >= 0xE000 && r < 0xE000+rune(len(markdownEscapable)) {
+			return rune(markdownEscapable[r-0xE000])
+		}
+		return r
+	}, s)
+	return strings.TrimSpace(s)
+}
+
+// Shortens text to at most maxChars characters, preferring whole sentences, then whole words
+func summarize(text string, maxChars int) string {
+	if maxChars <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+
+	var summary string
+	for _, sentence := range splitSentences(text) {
+		next := sentence
+		if summary != "" {
+			next = summary + " " + sentence
+		}
+		if utf8.RuneCountInString(next) > maxChars {
+			break
+		}
+		summary = next
+	}
+	if summary != "" {
+		return summary
+	}
+
+	return truncateWords(text, maxChars)
+}
+
+// Shortens text to at most maxChars characters (including the ellipsis), cutting at a word boundary when possible
+func truncateWords(text string, maxChars int) string {
+	runes := []rune(text)
+	if len(runes) <= maxChars {
+		return text
+	}
+	if maxChars <= 0 {
+		return ""
+	}
+	if maxChars == 1 {
+		return ellipsis
+	}
+
+	cut := runes[:maxChars-1]
+	if !unicode.IsSpace(runes[maxChars-1]) {
+		for i := len(cut) - 1; i > 0; i-- {
+			if unicode.IsSpace(cut[i]) {
+				cut = cut[:i]
+				break
+			}
+		}
+	}
+	return strings.TrimRightFunc(string(cut), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + ellipsis
+}
+
+// Splits text into sentences
+func splitSentences(text string) []string {
+	var sentences []string
+	runes := []rune(strings.TrimSpace(text))
+	start := 0
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			if sentence := strings.TrimSpace(string(runes[start:i])); sentence != "" {
+				sentences = append(sentences, sentence)
+			}
+			start = i + 1
+			continue
+		}
+		if runes[i] != '.' && runes[i] != '!' && runes[i] != '?' && runes[i] != '。' {
+			continue
+		}
+
+		// include closing quotes and brackets
+		end := i + 1
+		for end < len(runes) && strings.ContainsRune(`"'”’)]`, runes[end]) {
+			end++
+		}
+		if end < len(runes) && !unicode.IsSpace(runes[end]) && runes[i] != '。' {
+			continue
+		}
+		if runes[i] == '.' && isAbbreviation(runes[start:i]) {
+			continue
+		}
+
+		if sentence := strings.TrimSpace(string(runes[start:end])); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+		i = end - 1
+	}
+
+	if sentence := strings.TrimSpace(string(runes[start:])); sentence != "" {
+		sentences = append(sentences, sentence)
+	}
+
+	return sentences
+}
+
+// Reports whether the last word of text is an abbreviation
+func isAbbreviation(text []rune) bool {
+	words := strings.Fields(string(text))
+	if len(words) == 0 {
+		return false
+	}
+	word := strings.ToLower(strings.TrimLeft(words[len(words)-1], `"'“‘(`))
+	return abbreviations[word] || utf8.RuneCountInString(word) == 1 && unicode.IsLetter([]rune(word)[0])
+}
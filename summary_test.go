@@ -0,0 +1,73 @@
+package holidays
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	text := RichText{Text: "Dr. Smith loves cats. They are great! Are they? Yes.\nSecond paragraph here."}
+
+	t.Run("keeps whole sentences", func(t *testing.T) {
+		assert.Equal(t, "Dr. Smith loves cats.", text.Summary(30))
+		assert.Equal(t, "Dr. Smith loves cats. They are great!", text.Summary(40))
+	})
+
+	t.Run("keeps short text", func(t *testing.T) {
+		assert.Equal(t, "Short.", RichText{Text: "Short."}.Summary(6))
+	})
+
+	t.Run("cuts long sentences at a word", func(t *testing.T) {
+		assert.Equal(t, "Dr. Smith…", text.Summary(15))
+		assert.Equal(t, "…", text.Summary(1))
+		assert.Equal(t, "", text.Summary(0))
+	})
+
+	t.Run("counts characters, not bytes", func(t *testing.T) {
+		unicode := RichText{Text: "Über café crème brûlée"}
+
+		assert.Equal(t, "Über café crème…", unicode.Summary(20))
+		assert.Equal(t, "日本語のテキスト…", RichText{Text: "日本語のテキストです"}.Summary(9))
+	})
+
+	t.Run("gets sentences", func(t *testing.T) {
+		assert.Equal(t, "Dr. Smith loves cats. They are great!", text.SummarySentences(2))
+		assert.Equal(t, "Dr. Smith loves cats. They are great! Are they? Yes. Second paragraph here.", text.SummarySentences(10))
+	})
+
+	t.Run("gets the first paragraph", func(t *testing.T) {
+		assert.Equal(t, "Dr. Smith loves cats. They are great! Are they? Yes.", text.FirstParagraph())
+
+		howToObserve := loadEventInfo(t).HowToObserve
+		assert.Equal(t, "Spend the day playing with your cat", howToObserve.FirstParagraph()[:35])
+		assert.NotContains(t, howToObserve.FirstParagraph(), "If there is great danger")
+	})
+
+	t.Run("falls back to Markdown", func(t *testing.T) {
+		markdown := RichText{Markdown: "Visit the [shelter](https://example.com). **Adopt** a cat!\r\n\r\nMore."}
+
+		assert.Equal(t, "Visit the shelter.", markdown.SummarySentences(1))
+		assert.Equal(t, "Visit the shelter. Adopt a cat!", markdown.FirstParagraph())
+	})
+}
+
+func TestMarkdownToPlain(t *testing.T) {
+	t.Run("keeps link text", func(t *testing.T) {
+		pattern := loadEventInfo(t).Patterns[0]
+
+		assert.Equal(t, "annually on August 8th", MarkdownToPlain(pattern.ObservedMarkdown))
+		assert.Equal(t, loadEventInfo(t).HowToObserve.Text[:200], loadEventInfo(t).HowToObserve.PlainFromMarkdown()[:200])
+	})
+
+	t.Run("removes formatting", func(t *testing.T) {
+		assert.Equal(t, "Title\nbold, italic, also italic, code and snake_case_name\nitem one\nitem two\nquoted *literal*\nalt text",
+			MarkdownToPlain("# Title\n**bold**, *italic*, _also italic_, `code` and snake_case_name\n- item one\n1. item two\n> quoted \\*literal\\*\n![alt text](https://example.com/cat.jpg)"))
+	})
+
+	t.Run("keeps numbers outside lists", func(t *testing.T) {
+		assert.Equal(t, "1999. It was a good year.", MarkdownToPlain("1999. It was a good year."))
+		assert.Equal(t, "Steps:\nfirst\nsecond\n\nthird", MarkdownToPlain("Steps:\n1. first\n2) second\n\n3. third"))
+		assert.Equal(t, "item\nText.\n1999. It was a good year.", MarkdownToPlain("- item\nText.\n1999. It was a good year."))
+	})
+}
@@ -0,0 +1,142 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// How many GetEvents requests Upcoming makes at once
+const upcomingConcurrency = 4
+
+// Options for Upcoming
+type UpcomingOptions struct {
+	Timezone     string // IANA Time Zone for calculating dates. Defaults to the Client's default, or the Location of from.
+	Adult        bool   // Include events that may be unsafe for viewing at work or by children. Defaults to the Client's default, or false.
+	ExcludeAdult bool   // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
+}
+
+// An Event occurring within an Upcoming window
+type UpcomingOccurrence struct {
+	EventSummary
+	Start         time.Time // The first day of the occurrence within the window, at midnight in the requested timezone
+	End           time.Time // The last day of the occurrence within the window. The same as Start for single-day Events.
+	DaysUntil     int       // How many days after the first day of the window the occurrence starts. 0 for today, or if it started earlier.
+	Multiday      bool      // Whether the Event is a multi-day Event
+	StartedBefore bool      // Whether the multi-day Event started before the window
+	EndsAfter     bool      // Whether the multi-day Event continues after the window
+}
+
+// Gets the Events occurring in the window days starting on from's date, sorted by when they start.
+// Multi-day Events are listed once, spanning the days they are observed.
+func (c *Client) Upcoming(ctx context.Context, from time.Time, window int, opts UpcomingOptions) ([]UpcomingOccurrence, error) {
+	if window < 1 {
+		return nil, errors.New("window must be at least 1 day")
+	}
+
+	timezone := opts.Timezone
+	if timezone == "" {
+		timezone = c.defaults.Timezone
+	}
+	loc := from.Location()
+	if timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("can't load timezone %q: %w", timezone, err)
+		}
+	} else {
+		timezone = TimezoneName(loc)
+	}
+
+	// one extra day tells whether multi-day Events continue after the window
+	from = from.In(loc)
+	first := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
+	days := make([]*GetEventsResponse, window+1)
+	errs := make([]error, window+1)
+	semaphore := make(chan struct{}, upcomingConcurrency)
+	var wg sync.WaitGroup
+
+	for i := range days {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+				days[i], errs[i] = c.getEvents(ctx, GetEventsRequest{
+					Date:         first.AddDate(0, 0, i).Format(DateLayout),
+					Timezone:     timezone,
+					Adult:        opts.Adult,
+					ExcludeAdult: opts.ExcludeAdult,
+				})
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return upcomingOccurrences(first, days), nil
+}
+
+// Combines each day's Events into occurrences, the last day only telling whether multi-day Events continue
+func upcomingOccurrences(first time.Time, days []*GetEventsResponse) []UpcomingOccurrence {
+	window := len(days) - 1
+	ongoing := func(day int, id string) bool {
+		for _, event := range days[day].MultidayOngoing {
+			if event.Id == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	var occurrences []UpcomingOccurrence
+	addMultiday := func(event EventSummary, day int, startedBefore bool) {
+		end := day
+		for end+1 < window && ongoing(end+1, event.Id) {
+			end++
+		}
+		occurrences = append(occurrences, UpcomingOccurrence{
+			EventSummary:  event,
+			Start:         first.AddDate(0, 0, day),
+			End:           first.AddDate(0, 0, end),
+			DaysUntil:     day,
+			Multiday:      true,
+			StartedBefore: startedBefore,
+			EndsAfter:     end == window-1 && ongoing(window, event.Id),
+		})
+	}
+
+	for _, event := range days[0].MultidayOngoing {
+		addMultiday(event, 0, true)
+	}
+	for day := 0; day < window; day++ {
+		date := first.AddDate(0, 0, day)
+		for _, event := range days[day].Events {
+			occurrences = append(occurrences, UpcomingOccurrence{EventSummary: event, Start: date, End: date, DaysUntil: day})
+		}
+		for _, event := range days[day].MultidayStarting {
+			addMultiday(event, day, false)
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].DaysUntil < occurrences[j].DaysUntil
+	})
+	return occurrences
+}
@@ -0,0 +1,173 @@
+package holidays
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func mockEventsDay(date string, body map[string]any) {
+	gock.New("https://api.apilayer.com/checkiday/").
+		Get("/events").
+		MatchParam("date", date).
+		Reply(200).
+		JSON(body)
+}
+
+func upcomingEvent(id string) map[string]string {
+	return map[string]string{"id": id, "name": id + " Day", "url": "https://www.checkiday.com/" + id}
+}
+
+func TestUpcoming(t *testing.T) {
+	chicago, _ := time.LoadLocation("America/Chicago")
+
+	t.Run("combines days into sorted occurrences", func(t *testing.T) {
+		defer gock.Off()
+
+		mockEventsDay("05/05/2025", map[string]any{
+			"events":           []any{upcomingEvent("a")},
+			"multiday_ongoing": []any{upcomingEvent("m1")},
+		})
+		mockEventsDay("05/06/2025", map[string]any{
+			"multiday_starting": []any{upcomingEvent("m2")},
+			"multiday_ongoing":  []any{upcomingEvent("m1")},
+		})
+		mockEventsDay("05/07/2025", map[string]any{
+			"events":           []any{upcomingEvent("b")},
+			"multiday_ongoing": []any{upcomingEvent("m2")},
+		})
+		mockEventsDay("05/08/2025", map[string]any{
+			"multiday_ongoing": []any{upcomingEvent("m2")},
+		})
+
+		api, _ := New("abc123")
+		from := time.Date(2025, time.May, 5, 10, 0, 0, 0, chicago)
+		occurrences, err := api.Upcoming(context.Background(), from, 3, UpcomingOptions{})
+
+		assert.Nil(t, err)
+		day := func(d int) time.Time {
+			return time.Date(2025, time.May, d, 0, 0, 0, 0, chicago)
+		}
+		assert.Equal(t, []UpcomingOccurrence{
+			{EventSummary: EventSummary{Id: "m1", Name: "m1 Day", Url: "https://www.checkiday.com/m1"}, Start: day(5), End: day(6), DaysUntil: 0, Multiday: true, StartedBefore: true},
+			{EventSummary: EventSummary{Id: "a", Name: "a Day", Url: "https://www.checkiday.com/a"}, Start: day(5), End: day(5), DaysUntil: 0},
+			{EventSummary: EventSummary{Id: "m2", Name: "m2 Day", Url: "https://www.checkiday.com/m2"}, Start: day(6), End: day(7), DaysUntil: 1, Multiday: true, EndsAfter: true},
+			{EventSummary: EventSummary{Id: "b", Name: "b Day", Url: "https://www.checkiday.com/b"}, Start: day(7), End: day(7), DaysUntil: 2},
+		}, occurrences)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("uses the requested timezone", func(t *testing.T) {
+		defer gock.Off()
+
+		// 3 AM UTC on May 5th is still May 4th in Chicago
+		for _, date := range []string{"05/04/2025", "05/05/2025"} {
+			gock.New("https://api.apilayer.com/checkiday/").
+				Get("/events").
+				MatchParam("date", date).
+				MatchParam("timezone", "America/Chicago").
+				Reply(200).
+				JSON(map[string]any{"events": []any{upcomingEvent("a")}})
+		}
+
+		api, _ := New("abc123")
+		from := time.Date(2025, time.May, 5, 3, 0, 0, 0, time.UTC)
+		occurrences, err := api.Upcoming(context.Background(), from, 1, UpcomingOptions{Timezone: "America/Chicago"})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(occurrences))
+		assert.Equal(t, time.Date(2025, time.May, 4, 0, 0, 0, 0, chicago), occurrences[0].Start)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("leaves out time zones without an IANA name", func(t *testing.T) {
+		defer gock.Off()
+
+		// 3 AM UTC on May 5th is noon in UTC+9
+		for _, date := range []string{"05/05/2025", "05/06/2025"} {
+			gock.New("https://api.apilayer.com/checkiday/").
+				Get("/events").
+				MatchParam("date", date).
+				AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+					return !req.URL.Query().Has("timezone"), nil
+				}).
+				Reply(200).
+				JSON(map[string]any{})
+		}
+
+		api, _ := New("abc123")
+		from := time.Date(2025, time.May, 5, 3, 0, 0, 0, time.UTC).In(time.FixedZone("UTC+9", 9*60*60))
+		_, err := api.Upcoming(context.Background(), from, 1, UpcomingOptions{})
+
+		assert.Nil(t, err)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("defaults to the Client's timezone", func(t *testing.T) {
+		defer gock.Off()
+
+		for _, date := range []string{"05/04/2025", "05/05/2025"} {
+			gock.New("https://api.apilayer.com/checkiday/").
+				Get("/events").
+				MatchParam("date", date).
+				MatchParam("timezone", "America/Chicago").
+				MatchParam("adult", "true").
+				Reply(200).
+				JSON(map[string]any{})
+		}
+
+		api, _ := New("abc123", WithDefaults(Defaults{Timezone: "America/Chicago", Adult: true}))
+		from := time.Date(2025, time.May, 5, 3, 0, 0, 0, time.UTC)
+		occurrences, err := api.Upcoming(context.Background(), from, 1, UpcomingOptions{})
+
+		assert.Nil(t, err)
+		assert.Empty(t, occurrences)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("validates options", func(t *testing.T) {
+		api, _ := New("abc123")
+
+		occurrences, err := api.Upcoming(context.Background(), time.Now(), 0, UpcomingOptions{})
+		assert.Nil(t, occurrences)
+		assert.EqualError(t, err, "window must be at least 1 day")
+
+		occurrences, err = api.Upcoming(context.Background(), time.Now(), 14, UpcomingOptions{Timezone: "Nowhere/Special"})
+		assert.Nil(t, occurrences)
+		assert.ErrorContains(t, err, `can't load timezone "Nowhere/Special"`)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		api, _ := New("abc123")
+		occurrences, err := api.Upcoming(ctx, time.Now(), 14, UpcomingOptions{})
+
+		assert.Nil(t, occurrences)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("fails on request errors", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			Times(2).
+			ReplyError(errors.New("boom"))
+
+		api, _ := New("abc123")
+		occurrences, err := api.Upcoming(context.Background(), time.Now(), 1, UpcomingOptions{})
+
+		assert.Nil(t, occurrences)
+		assert.ErrorContains(t, err, "boom")
+	})
+}
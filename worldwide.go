@@ -0,0 +1,88 @@
+package holidays
+
+import (
+	"errors"
+	"time"
+)
+
+// The Request struct for calling GetEventsWorldwide
+type GetEventsWorldwideRequest struct {
+	Locations    []*time.Location // The time zones to get today's Events for. Required.
+	At           time.Time        // The instant that decides each zone's local date. Defaults to now.
+	Adult        bool             // Include events that may be unsafe for viewing at work or by children. Defaults to the Client's default, or false.
+	ExcludeAdult bool             // Exclude events that may be unsafe for viewing at work or by children, even if the Client defaults to including them.
+}
+
+// The Response struct returned by GetEventsWorldwide
+type GetEventsWorldwideResponse struct {
+	Zones  []ZoneEvents   // Each zone's Events, in the order of the requested Locations
+	Events []EventSummary // The unique Events of every zone, including multi-day Events, in order of first appearance
+}
+
+// A time zone's Events for its local date
+type ZoneEvents struct {
+	Location *time.Location     // The time zone
+	Date     string             // The zone's local date, formatted as MM/DD/YYYY
+	Response *GetEventsResponse // The Events of the date, shared by zones on the same date
+}
+
+// Gets the Events of each zone's local date.
+// Zones on the same local date share one GetEvents request, so there are at most as many requests as distinct dates.
+func (c *Client) GetEventsWorldwide(req GetEventsWorldwideRequest) (*GetEventsWorldwideResponse, error) {
+	if len(req.Locations) == 0 {
+		return nil, errors.New("at least one location is required")
+	}
+	at := req.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	responses := map[string]*GetEventsResponse{}
+	var worldwide GetEventsWorldwideResponse
+	seen := map[string]bool{}
+
+	for _, loc := range req.Locations {
+		if loc == nil {
+			return nil, errors.New("locations can't be nil")
+		}
+		date := at.In(loc).Format(DateLayout)
+
+		res, ok := responses[date]
+		if !ok {
+			var err error
+			res, err = c.GetEvents(GetEventsRequest{
+				Date:         date,
+				Timezone:     TimezoneName(loc),
+				Adult:        req.Adult,
+				ExcludeAdult: req.ExcludeAdult,
+			})
+			if err != nil {
+				return nil, err
+			}
+			responses[date] = res
+
+			for _, events := range [][]EventSummary{res.Events, res.MultidayStarting, res.MultidayOngoing} {
+				for _, event := range events {
+					if !seen[event.Id] {
+						seen[event.Id] = true
+						worldwide.Events = append(worldwide.Events, event)
+					}
+				}
+			}
+		}
+
+		worldwide.Zones = append(worldwide.Zones, ZoneEvents{Location: loc, Date: date, Response: res})
+	}
+
+	return &worldwide, nil
+}
+
+// Gets the Events of the zone with the given name, e.g. "Asia/Tokyo"
+func (r *GetEventsWorldwideResponse) Zone(name string) (*GetEventsResponse, bool) {
+	for _, zone := range r.Zones {
+		if zone.Location.String() == name {
+			return zone.Response, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,160 @@
+package holidays
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEventsWorldwide(t *testing.T) {
+	chicago, _ := time.LoadLocation("America/Chicago")
+	newYork, _ := time.LoadLocation("America/New_York")
+	london, _ := time.LoadLocation("Europe/London")
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	// 10 PM May 4th in Chicago, 4 AM May 5th in London
+	at := time.Date(2025, time.May, 5, 3, 0, 0, 0, time.UTC)
+
+	t.Run("shares requests between zones on the same date", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/04/2025").
+			MatchParam("timezone", "America/Chicago").
+			Reply(200).
+			File("testdata/getEvents-parameters.json")
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/05/2025").
+			MatchParam("timezone", "Europe/London").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{
+			Locations: []*time.Location{chicago, london, newYork, tokyo},
+			At:        at,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(response.Zones))
+		assert.Equal(t, []string{"05/04/2025", "05/05/2025", "05/04/2025", "05/05/2025"}, []string{
+			response.Zones[0].Date, response.Zones[1].Date, response.Zones[2].Date, response.Zones[3].Date,
+		})
+		assert.Same(t, response.Zones[0].Response, response.Zones[2].Response)
+		assert.Same(t, response.Zones[1].Response, response.Zones[3].Response)
+		assert.Equal(t, 8, len(response.Events))
+		assert.Equal(t, response.Zones[0].Response.Events[0].Id, response.Events[0].Id)
+
+		tokyoEvents, ok := response.Zone("Asia/Tokyo")
+		assert.True(t, ok)
+		assert.Same(t, response.Zones[1].Response, tokyoEvents)
+		_, ok = response.Zone("Europe/Paris")
+		assert.False(t, ok)
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("de-duplicates events across dates", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/04/2025").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/05/2025").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{
+			Locations: []*time.Location{chicago, tokyo},
+			At:        at,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(response.Events))
+
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("leaves out time zones without an IANA name", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/05/2025").
+			AddMatcher(func(req *http.Request, _ *gock.Request) (bool, error) {
+				return !req.URL.Query().Has("timezone"), nil
+			}).
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		response, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{
+			Locations: []*time.Location{time.FixedZone("UTC+9", 9*60*60)},
+			At:        at,
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, "05/05/2025", response.Zones[0].Date)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("passes adult settings", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			MatchParam("date", "05/05/2025").
+			MatchParam("adult", "true").
+			Reply(200).
+			File("testdata/getEvents-default.json")
+
+		api, _ := New("abc123")
+		_, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{
+			Locations: []*time.Location{tokyo},
+			At:        at,
+			Adult:     true,
+		})
+
+		assert.Nil(t, err)
+		assert.True(t, gock.IsDone())
+	})
+
+	t.Run("requires locations", func(t *testing.T) {
+		api, _ := New("abc123")
+
+		response, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{})
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "at least one location is required")
+
+		response, err = api.GetEventsWorldwide(GetEventsWorldwideRequest{Locations: []*time.Location{nil}})
+		assert.Nil(t, response)
+		assert.EqualError(t, err, "locations can't be nil")
+	})
+
+	t.Run("fails on request errors", func(t *testing.T) {
+		defer gock.Off()
+
+		gock.New("https://api.apilayer.com/checkiday/").
+			Get("/events").
+			ReplyError(errors.New("boom"))
+
+		api, _ := New("abc123")
+		response, err := api.GetEventsWorldwide(GetEventsWorldwideRequest{
+			Locations: []*time.Location{tokyo},
+			At:        at,
+		})
+
+		assert.Nil(t, response)
+		assert.NotNil(t, err)
+	})
+}